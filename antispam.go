@@ -0,0 +1,143 @@
+package aform
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TimestampFieldName is the name of the hidden field rendered by a form
+// created with WithTimestamp.
+const TimestampFieldName = "form_timestamp"
+
+// Error codes of the anti-spam validations. See WithHoneypot and
+// WithTimestamp.
+const (
+	HoneypotErrorCode         = "honeypot"
+	TooFastErrorCode          = "too_fast"
+	ExpiredErrorCode          = "expired"
+	InvalidTimestampErrorCode = "invalid_timestamp"
+)
+
+// English error messages of the anti-spam validations.
+const (
+	HoneypotErrorMessageEn         = "This submission has been rejected"
+	TooFastErrorMessageEn          = "The form has been submitted too quickly. Wait a moment and submit it again"
+	ExpiredErrorMessageEn          = "The form has expired. Reload the page and submit it again"
+	InvalidTimestampErrorMessageEn = "The form is invalid. Reload the page and submit it again"
+)
+
+// French error messages of the anti-spam validations.
+const (
+	HoneypotErrorMessageFr         = "Cet envoi a été rejeté"
+	TooFastErrorMessageFr          = "Le formulaire a été envoyé trop rapidement. Patientez un instant et envoyez-le à nouveau"
+	ExpiredErrorMessageFr          = "Le formulaire a expiré. Rechargez la page et envoyez-le à nouveau"
+	InvalidTimestampErrorMessageFr = "Le formulaire est invalide. Rechargez la page et envoyez-le à nouveau"
+)
+
+// WithHoneypot returns a FormOption that adds a honeypot field named name.
+// AsDiv renders it as a text input hidden with CSS. Humans don't see it and
+// leave it empty, bots filling all the inputs don't. If a value is submitted,
+// an error with the code HoneypotErrorCode is added to the non-field errors.
+// Choose a name attractive for bots, like "website", and different from the
+// form fields names. The honeypot is not a field: it is not returned by
+// Fields and its value is not in CleanedData.
+func WithHoneypot(name string) FormOption {
+	return func(f *Form) error {
+		if len(name) == 0 {
+			return fmt.Errorf("honeypot name can't be empty")
+		}
+		f.honeypot = normalizedName(name)
+		return nil
+	}
+}
+
+// WithTimestamp returns a FormOption that adds a hidden field containing the
+// time the form is rendered, signed with secret. A submission faster than min
+// after rendering adds an error with the code TooFastErrorCode to the
+// non-field errors. A submission older than max adds an error with the code
+// ExpiredErrorCode. A missing or modified timestamp adds an error with the
+// code InvalidTimestampErrorCode. max 0 means no maximum. The timestamp is
+// not a field: it is not returned by Fields and its value is not in
+// CleanedData.
+func WithTimestamp(secret []byte, min, max time.Duration) FormOption {
+	return func(f *Form) error {
+		if len(secret) == 0 {
+			return fmt.Errorf("timestamp secret can't be empty")
+		}
+		if min < 0 || max < 0 {
+			return fmt.Errorf("timestamp durations can't be negative")
+		}
+		if max > 0 && max <= min {
+			return fmt.Errorf("timestamp max %s must be greater than min %s", max, min)
+		}
+		f.timestampSecret = secret
+		f.timestampMin = min
+		f.timestampMax = max
+		return nil
+	}
+}
+
+func (f *Form) honeypotEnabled() bool {
+	return len(f.honeypot) > 0
+}
+
+func (f *Form) honeypotFieldName() string {
+	return prefixedName(f.prefix, f.honeypot)
+}
+
+func (f *Form) timestampEnabled() bool {
+	return len(f.timestampSecret) > 0
+}
+
+func (f *Form) timestampFieldName() string {
+	return prefixedName(f.prefix, TimestampFieldName)
+}
+
+// timestampSigningPurpose is the purpose of the signature of the timestamp
+// field. See sign.
+const timestampSigningPurpose = "aform.timestamp:"
+
+// timestampValue returns the signed value of the timestamp field for the
+// current time.
+func (f *Form) timestampValue() string {
+	return sign(f.timestampSecret, timestampSigningPurpose, strconv.FormatInt(time.Now().Unix(), 10))
+}
+
+// antiSpamErrors returns the errors of the honeypot and timestamp
+// validations. It returns nil if they are disabled or valid.
+func (f *Form) antiSpamErrors() []Error {
+	var errs []Error
+	if f.honeypotEnabled() && len(f.protectionData[f.honeypotFieldName()]) > 0 {
+		errs = append(errs, antiSpamError(HoneypotErrorCode, HoneypotErrorMessageEn, HoneypotErrorMessageFr))
+	}
+	if f.timestampEnabled() {
+		if err, ok := f.timestampError(); ok {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (f *Form) timestampError() (Error, bool) {
+	value, err := unsign(f.timestampSecret, timestampSigningPurpose, f.protectionData[f.timestampFieldName()])
+	if err != nil {
+		return antiSpamError(InvalidTimestampErrorCode, InvalidTimestampErrorMessageEn, InvalidTimestampErrorMessageFr), true
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return antiSpamError(InvalidTimestampErrorCode, InvalidTimestampErrorMessageEn, InvalidTimestampErrorMessageFr), true
+	}
+	elapsed := time.Since(time.Unix(seconds, 0))
+	if elapsed < f.timestampMin {
+		return antiSpamError(TooFastErrorCode, TooFastErrorMessageEn, TooFastErrorMessageFr), true
+	}
+	if f.timestampMax > 0 && elapsed > f.timestampMax {
+		return antiSpamError(ExpiredErrorCode, ExpiredErrorMessageEn, ExpiredErrorMessageFr), true
+	}
+	return Error{}, false
+}
+
+func antiSpamError(code, en, fr string) Error {
+	return ErrorWrap(simpleError{code: code, fr: fr, en: en})
+}
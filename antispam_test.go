@@ -0,0 +1,159 @@
+package aform_test
+
+import (
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var timestampSecret = []byte("timestamp secret")
+
+func signedTimestamp(secret []byte, t time.Time) string {
+	return aform.ExportSign(secret, aform.ExportTimestampSigningPurpose, strconv.FormatInt(t.Unix(), 10))
+}
+
+func TestWithHoneypot(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string][]string
+		wantCode string
+	}{
+		{
+			name: "honeypot empty",
+			data: map[string][]string{"name": {"Jane"}, "website": {""}},
+		},
+		{
+			name: "honeypot missing",
+			data: map[string][]string{"name": {"Jane"}},
+		},
+		{
+			name:     "honeypot filled",
+			data:     map[string][]string{"name": {"Jane"}, "website": {"https://spam.example"}},
+			wantCode: aform.HoneypotErrorCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			f := aform.Must(aform.New(aform.WithHoneypot("Website"), aform.WithStrictBinding(), aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+			f.BindData(tt.data)
+			a.Equal(tt.wantCode == "", f.IsValid())
+			a.Equal(aform.CleanedData{"name": {"Jane"}}, f.CleanedData())
+			if tt.wantCode == "" {
+				a.Empty(f.NonFieldErrors())
+				return
+			}
+			if a.Len(f.NonFieldErrors(), 1) {
+				a.Equal(tt.wantCode, f.NonFieldErrors()[0].Code())
+			}
+		})
+	}
+}
+
+func TestWithHoneypot_AsDiv(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithPrefix("p"), aform.WithHoneypot("website"), aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+	f.BindData(map[string][]string{"p-name": {"Jane"}, "p-website": {"spam"}})
+	a.False(f.IsValid())
+	expected := `
+<ul class="errorlist nonfield"><li>This submission has been rejected</li></ul>
+<div style="display:none" aria-hidden="true"><input type="text" name="p-website" autocomplete="off" tabindex="-1"></div>
+<div><label for="id_p-name">Name</label><input type="text" name="p-name" value="Jane" id="id_p-name" maxlength="256" required></div>`
+	a.Equal(expected, string(f.AsDiv()))
+}
+
+func TestWithTimestamp(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		value    []string
+		wantCode string
+	}{
+		{
+			name:  "valid",
+			value: []string{signedTimestamp(timestampSecret, now.Add(-time.Minute))},
+		},
+		{
+			name:     "too fast",
+			value:    []string{signedTimestamp(timestampSecret, now)},
+			wantCode: aform.TooFastErrorCode,
+		},
+		{
+			name:     "expired",
+			value:    []string{signedTimestamp(timestampSecret, now.Add(-2*time.Hour))},
+			wantCode: aform.ExpiredErrorCode,
+		},
+		{
+			name:     "missing",
+			wantCode: aform.InvalidTimestampErrorCode,
+		},
+		{
+			name:     "signed with another secret",
+			value:    []string{signedTimestamp([]byte("other"), now.Add(-time.Minute))},
+			wantCode: aform.InvalidTimestampErrorCode,
+		},
+		{
+			name:     "signed value is not a timestamp",
+			value:    []string{aform.ExportSign(timestampSecret, aform.ExportTimestampSigningPurpose, "yesterday")},
+			wantCode: aform.InvalidTimestampErrorCode,
+		},
+		{
+			name:     "value signed for another purpose",
+			value:    []string{aform.ExportSign(timestampSecret, "", strconv.FormatInt(now.Add(-time.Minute).Unix(), 10))},
+			wantCode: aform.InvalidTimestampErrorCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			f := aform.Must(aform.New(aform.WithTimestamp(timestampSecret, 3*time.Second, time.Hour), aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+			data := map[string][]string{"name": {"Jane"}}
+			if tt.value != nil {
+				data[aform.TimestampFieldName] = tt.value
+			}
+			f.BindData(data)
+			a.Equal(tt.wantCode == "", f.IsValid())
+			a.Equal(aform.CleanedData{"name": {"Jane"}}, f.CleanedData())
+			if tt.wantCode == "" {
+				a.Empty(f.NonFieldErrors())
+				return
+			}
+			if a.Len(f.NonFieldErrors(), 1) {
+				a.Equal(tt.wantCode, f.NonFieldErrors()[0].Code())
+			}
+		})
+	}
+}
+
+func TestWithTimestamp_AsDiv(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithTimestamp(timestampSecret, 0, 0), aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+	html := string(f.AsDiv())
+	matches := regexp.MustCompile(`<input type="hidden" name="form_timestamp" value="([^"]+)">`).FindStringSubmatch(html)
+	if a.Len(matches, 2) {
+		// The rendered timestamp is accepted right away because min is 0.
+		submitted := aform.Must(aform.New(aform.WithTimestamp(timestampSecret, 0, 0), aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+		submitted.BindData(map[string][]string{"name": {"Jane"}, aform.TimestampFieldName: {matches[1]}})
+		a.True(submitted.IsValid())
+	}
+}
+
+func TestWithTimestamp_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  aform.FormOption
+	}{
+		{name: "empty secret", opt: aform.WithTimestamp(nil, 0, 0)},
+		{name: "negative min", opt: aform.WithTimestamp(timestampSecret, -time.Second, 0)},
+		{name: "max lower than min", opt: aform.WithTimestamp(timestampSecret, time.Minute, time.Second)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := aform.New(tt.opt)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package aform
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"fmt"
+	"html/template"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultArithmeticCaptchaTTL = 10 * time.Minute
+
+// verify interface compliance
+var _ CaptchaProvider = (*ArithmeticCaptchaProvider)(nil)
+
+// ArithmeticCaptchaProvider is a CaptchaProvider asking to add two numbers
+// between 1 and 9. It doesn't need any external service. The challenge is
+// rendered with a hidden token containing a nonce and an HMAC of the answer
+// computed with a secret. The answer is not in the token. Nonces are issued
+// and consumed with a TokenStore, so a token can be verified only once.
+type ArithmeticCaptchaProvider struct {
+	secret []byte
+	ttl    time.Duration
+	store  TokenStore
+}
+
+// NewArithmeticCaptchaProvider returns an ArithmeticCaptchaProvider signing
+// its tokens with secret. A challenge expires after ttl. If ttl is 0, it
+// expires after 10 minutes. Nonces are kept in a MemoryTokenStore, use
+// SetTokenStore to share them between several instances of the application.
+func NewArithmeticCaptchaProvider(secret []byte, ttl time.Duration) (*ArithmeticCaptchaProvider, error) {
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("captcha secret can't be empty")
+	}
+	if ttl < 0 {
+		return nil, fmt.Errorf("captcha ttl can't be negative")
+	}
+	if ttl == 0 {
+		ttl = defaultArithmeticCaptchaTTL
+	}
+	return &ArithmeticCaptchaProvider{secret: secret, ttl: ttl, store: NewMemoryTokenStore(ttl)}, nil
+}
+
+// SetTokenStore sets the TokenStore issuing and consuming the nonces of the
+// challenges. Challenges rendered before the change can't be verified anymore.
+func (p *ArithmeticCaptchaProvider) SetTokenStore(store TokenStore) {
+	p.store = store
+}
+
+// Render renders the question in a <span> tag with the CSS class captcha,
+// followed by the hidden token and the answer input. e.g.
+//
+//	<span class="captcha">3 + 4 =</span>
+//	<input type="hidden" name="captcha" value="...">
+//	<input type="text" name="captcha" autocomplete="off" inputmode="numeric">
+func (p *ArithmeticCaptchaProvider) Render(name string, attrs map[string]string) template.HTML {
+	a, b := randomInt(9)+1, randomInt(9)+1
+	// If the store fails, the challenge is rendered without a nonce and
+	// can't be verified.
+	nonce, _ := p.store.Issue()
+	token := p.token(nonce, time.Now().Add(p.ttl).Unix(), a+b)
+	answerAttrs := tmplAttrs{"autocomplete": "off", "inputmode": "numeric"}
+	for k, v := range attrs {
+		answerAttrs[k] = v
+	}
+	var sb strings.Builder
+	sb.WriteString(`<span class="captcha">`)
+	sb.WriteString(template.HTMLEscapeString(fmt.Sprintf("%d + %d =", a, b)))
+	sb.WriteString(`</span>`)
+	sb.WriteString(string(mustInputTemplate(&widgetInput{Type: HiddenInput, Name: name, Value: token, Attrs: tmplAttrs{}})))
+	sb.WriteString(string(mustInputTemplate(&widgetInput{Type: TextInput, Name: name, Attrs: answerAttrs})))
+	return template.HTML(sb.String())
+}
+
+// Verify returns true if values are a valid token not expired followed by
+// the right answer. The nonce of the token is consumed before the answer is
+// checked: a token is rejected once it has been verified, even with a wrong
+// answer.
+func (p *ArithmeticCaptchaProvider) Verify(values []string) bool {
+	if len(values) != 2 {
+		return false
+	}
+	parts := strings.Split(values[0], signatureSeparator)
+	if len(parts) != 3 {
+		return false
+	}
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() > expiry {
+		return false
+	}
+	if consumed, err := p.store.Consume(parts[0]); err != nil || !consumed {
+		return false
+	}
+	answer, err := strconv.Atoi(strings.TrimSpace(values[1]))
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(values[0]), []byte(p.token(parts[0], expiry, answer)))
+}
+
+// captchaSigningPurpose is the purpose of the HMAC of the arithmetic captcha
+// tokens. See signature.
+const captchaSigningPurpose = "aform.captcha:"
+
+// token returns nonce and expiry followed by the signature of nonce, expiry
+// and answer. The answer is not in the token.
+func (p *ArithmeticCaptchaProvider) token(nonce string, expiry int64, answer int) string {
+	payload := nonce + signatureSeparator + strconv.FormatInt(expiry, 10)
+	return payload + signatureSeparator + signature(p.secret, captchaSigningPurpose, payload+signatureSeparator+strconv.Itoa(answer))
+}
+
+// randomInt returns a random int in [0, n).
+func randomInt(n int64) int {
+	i, err := rand.Int(rand.Reader, big.NewInt(n))
+	if err != nil {
+		panic("randomInt: " + err.Error())
+	}
+	return int(i.Int64())
+}
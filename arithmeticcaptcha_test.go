@@ -0,0 +1,134 @@
+package aform_test
+
+import (
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var arithmeticCaptchaPattern = regexp.MustCompile(`^<span class="captcha">(\d) \+ (\d) =</span><input type="hidden" name="captcha" value="([^"]+)"><input type="text" name="captcha" id="id_captcha" autocomplete="off" inputmode="numeric" required>$`)
+
+// solveArithmeticCaptcha returns the token and the answer of the challenge
+// rendered in html.
+func solveArithmeticCaptcha(t *testing.T, html string) (string, string) {
+	matches := arithmeticCaptchaPattern.FindStringSubmatch(html)
+	if len(matches) != 4 {
+		t.Fatalf("unexpected captcha widget: %s", html)
+	}
+	a, _ := strconv.Atoi(matches[1])
+	b, _ := strconv.Atoi(matches[2])
+	return matches[3], strconv.Itoa(a + b)
+}
+
+func newArithmeticCaptchaProvider(t *testing.T, secret []byte, ttl time.Duration) *aform.ArithmeticCaptchaProvider {
+	p, err := aform.NewArithmeticCaptchaProvider(secret, ttl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestArithmeticCaptchaProvider(t *testing.T) {
+	provider := newArithmeticCaptchaProvider(t, []byte("captcha secret"), time.Minute)
+	otherProvider := newArithmeticCaptchaProvider(t, []byte("other secret"), time.Minute)
+	expiredProvider := newArithmeticCaptchaProvider(t, []byte("captcha secret"), time.Nanosecond)
+	tests := []struct {
+		name     string
+		render   *aform.ArithmeticCaptchaProvider
+		verify   *aform.ArithmeticCaptchaProvider
+		answer   func(answer string) []string
+		expected bool
+	}{
+		{
+			name:     "right answer",
+			render:   provider,
+			verify:   provider,
+			answer:   func(answer string) []string { return []string{answer} },
+			expected: true,
+		},
+		{
+			name:     "right answer with spaces",
+			render:   provider,
+			verify:   provider,
+			answer:   func(answer string) []string { return []string{" " + answer + " "} },
+			expected: true,
+		},
+		{
+			name:   "wrong answer",
+			render: provider,
+			verify: provider,
+			answer: func(answer string) []string { return []string{answer + "0"} },
+		},
+		{
+			name:   "not a number",
+			render: provider,
+			verify: provider,
+			answer: func(string) []string { return []string{"seven"} },
+		},
+		{
+			name:   "missing answer",
+			render: provider,
+			verify: provider,
+			answer: func(string) []string { return nil },
+		},
+		{
+			name:   "token signed with another secret",
+			render: otherProvider,
+			verify: provider,
+			answer: func(answer string) []string { return []string{answer} },
+		},
+		{
+			name:   "expired token",
+			render: expiredProvider,
+			verify: expiredProvider,
+			answer: func(answer string) []string { return []string{answer} },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			fld := aform.Must(aform.NewCaptchaField("Captcha", tt.render))
+			token, answer := solveArithmeticCaptcha(t, string(fld.Widget()))
+			if tt.render == expiredProvider {
+				time.Sleep(1100 * time.Millisecond)
+			}
+			a.Equal(tt.expected, tt.verify.Verify(append([]string{token}, tt.answer(answer)...)))
+		})
+	}
+}
+
+func TestArithmeticCaptchaProvider_Verify_replay(t *testing.T) {
+	a := assert.New(t)
+	provider := newArithmeticCaptchaProvider(t, []byte("captcha secret"), time.Minute)
+	fld := aform.Must(aform.NewCaptchaField("Captcha", provider))
+	token, answer := solveArithmeticCaptcha(t, string(fld.Widget()))
+	a.True(provider.Verify([]string{token, answer}))
+	a.False(provider.Verify([]string{token, answer}))
+	// A wrong answer consumes the token too.
+	token, answer = solveArithmeticCaptcha(t, string(fld.Widget()))
+	a.False(provider.Verify([]string{token, answer + "0"}))
+	a.False(provider.Verify([]string{token, answer}))
+}
+
+func TestArithmeticCaptchaProvider_SetTokenStore(t *testing.T) {
+	a := assert.New(t)
+	store := aform.NewMemoryTokenStore(time.Minute)
+	renderer := newArithmeticCaptchaProvider(t, []byte("captcha secret"), time.Minute)
+	renderer.SetTokenStore(store)
+	verifier := newArithmeticCaptchaProvider(t, []byte("captcha secret"), time.Minute)
+	token, answer := solveArithmeticCaptcha(t, string(aform.Must(aform.NewCaptchaField("Captcha", renderer)).Widget()))
+	a.False(verifier.Verify([]string{token, answer}))
+	verifier.SetTokenStore(store)
+	a.True(verifier.Verify([]string{token, answer}))
+}
+
+func TestNewArithmeticCaptchaProvider_invalid(t *testing.T) {
+	a := assert.New(t)
+	_, err := aform.NewArithmeticCaptchaProvider(nil, 0)
+	a.Error(err)
+	_, err = aform.NewArithmeticCaptchaProvider([]byte("secret"), -time.Second)
+	a.Error(err)
+}
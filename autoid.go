@@ -42,14 +42,43 @@ func normalizedIDForField(fld fieldReader) string {
 	if !hasID(fld) {
 		return disabledAutoID
 	}
-	singleSpacePattern := regexp.MustCompile(`\s+`)
-	return fmt.Sprintf(fld.AutoID(), strings.ToLower(singleSpacePattern.ReplaceAllString(fld.Name(), "_")))
+	return fmt.Sprintf(fld.AutoID(), prefixedNameForField(fld))
+}
+
+// normalizedFocusableIDForField returns the ID of the element getting the
+// focus for the field. It's the ID of the first input for a widget rendered
+// as a list of inputs, e.g. "id_color_0" for a RadioSelect.
+func normalizedFocusableIDForField(fld *Field) string {
+	id := normalizedIDForField(fld)
+	if !hasID(fld) || !fld.widget.isMultipleInput() || len(fld.optionGroups) == 0 {
+		return id
+	}
+	for name := range fld.optionGroups[0] {
+		if len(name) > 0 {
+			return normalizedSubGroupID(id, 0, 0)
+		}
+	}
+	return normalizedGroupID(id, 0)
 }
 
 func normalizedNameForField(fld fieldReader) string {
 	return normalizedName(fld.Name())
 }
 
+// prefixedNameForField returns the normalized name of the field with the
+// form prefix added at the beginning. It is the value of the HTML name
+// attribute.
+func prefixedNameForField(fld fieldReader) string {
+	return prefixedName(fld.Prefix(), normalizedNameForField(fld))
+}
+
+func prefixedName(prefix, name string) string {
+	if len(prefix) == 0 {
+		return name
+	}
+	return prefix + "-" + name
+}
+
 func normalizedName(name string) string {
 	singleSpacePattern := regexp.MustCompile(`\s+`)
 	return strings.ToLower(singleSpacePattern.ReplaceAllString(name, "_"))
@@ -68,6 +97,20 @@ func normalizedDescribedByIDForErr(fld fieldReader, index int) string {
 	return fmt.Sprintf("err_%d_", index) + normalizedIDForField(fld)
 }
 
+func normalizedDescribedByIDWarningList(fld fieldReader, numberOfWarnings int) []string {
+	ids := make([]string, numberOfWarnings)
+	for i := 0; i < numberOfWarnings; i++ {
+		ids[i] = normalizedDescribedByIDForWarning(fld, i)
+	}
+	return ids
+}
+
+// normalizedDescribedByIDForWarning generates IDs for warnings pointed by the
+// aria-describedby tag.
+func normalizedDescribedByIDForWarning(fld fieldReader, index int) string {
+	return fmt.Sprintf("warn_%d_", index) + normalizedIDForField(fld)
+}
+
 func normalizedGroupID(normalizedID string, index uint) string {
 	return normalizedID + fmt.Sprintf("_%d", index)
 }
@@ -61,10 +61,16 @@ func (fld *BooleanField) Clean(value string) (string, []Error) {
 	if fld.notRequired && len(sanitizedValue) == 0 {
 		return fld.EmptyValue(), nil
 	}
-	fld.errors = customizeErrors(fld.validateFunc(sanitizedValue, !fld.notRequired), fld.customErrors)
+	fld.errors = customizeErrors(fld.validate(sanitizedValue, !fld.notRequired), fld.customErrors)
 	return sanitizedValue, fld.errors
 }
 
+// hasChanged returns true if the boolean value of the first sanitized value
+// is different from the initial one. e.g. "on" and "true" are the same.
+func (fld *BooleanField) hasChanged(values []string) bool {
+	return valueToBool(fld.sanitize(firstValue(values))) != valueToBool(fld.initialValue)
+}
+
 // EmptyValue returns the BooleanField empty value. The empty value is the
 // cleaned value returned by Clean when there is no data bound to the field.
 // A BooleanField empty value is always "off".
@@ -0,0 +1,103 @@
+package aform
+
+import (
+	"html/template"
+)
+
+// CaptchaErrorCode is the error code of the error returned when a
+// CaptchaField answer is wrong.
+const CaptchaErrorCode = "captcha"
+
+// English and French error messages of a wrong CaptchaField answer.
+const (
+	CaptchaErrorMessageEn = "Wrong answer, please try again"
+	CaptchaErrorMessageFr = "Mauvaise réponse, veuillez réessayer"
+)
+
+// CaptchaProvider defines the interface used by a CaptchaField to render a
+// challenge and to verify the answer. ArithmeticCaptchaProvider is a self-contained
+// implementation. Third-party services can be integrated by implementing this
+// interface.
+type CaptchaProvider interface {
+	// Render returns the HTML of a new challenge. name is the HTML name of the
+	// field: all the values needed by Verify must be submitted under name, in
+	// the document order. attrs are the attributes computed for the answer
+	// input like id, required and aria-describedby.
+	Render(name string, attrs map[string]string) template.HTML
+	// Verify returns true if values submitted under the field name contain
+	// the right answer to the challenge.
+	Verify(values []string) bool
+}
+
+// CaptchaField is a field type that renders a challenge with its
+// CaptchaProvider and validates the answer. It is always required and its
+// cleaned data is always empty. The answer is never rendered back: a new
+// challenge is rendered each time the form is displayed.
+type CaptchaField struct {
+	provider CaptchaProvider
+	*Field
+}
+
+// verify interface compliance
+var _ fieldInterface = (*CaptchaField)(nil)
+
+// NewCaptchaField creates a captcha field named name. The challenge is
+// rendered and verified by provider.
+func NewCaptchaField(name string, provider CaptchaProvider, opts ...FieldOption) (*CaptchaField, error) {
+	cf := &CaptchaField{
+		provider,
+		&Field{
+			name:        name,
+			errors:      []Error{},
+			fieldType:   CaptchaFieldType,
+			widget:      TextInput,
+			autoID:      defaultAutoID,
+			label:       name,
+			labelSuffix: defaultLabelSuffix,
+			locale:      defaultLanguage,
+			captcha:     provider,
+		},
+	}
+	for _, opt := range opts {
+		if err := opt(cf.Field); err != nil {
+			return nil, err
+		}
+	}
+	cf.notRequired = false
+	return cf, nil
+}
+
+func (fld *CaptchaField) field() *Field {
+	return fld.Field
+}
+
+// Clean verifies values with the CaptchaProvider. It returns an error with
+// the code CaptchaErrorCode if the answer is wrong. Cleaned values are always
+// empty.
+func (fld *CaptchaField) Clean(values []string) ([]string, []Error) {
+	fld.boundValues = nil
+	if fld.provider.Verify(values) {
+		fld.errors = []Error{}
+		return fld.EmptyValue(), nil
+	}
+	fld.errors = customizeErrors([]Error{captchaError}, fld.customErrors)
+	return fld.EmptyValue(), fld.errors
+}
+
+// hasChanged always returns false. A captcha answer is not data.
+func (fld *CaptchaField) hasChanged([]string) bool {
+	return false
+}
+
+// EmptyValue returns the CaptchaField empty value. It is always an empty
+// slice.
+func (fld *CaptchaField) EmptyValue() []string {
+	return []string{}
+}
+
+func (fld *Field) widgetCaptcha(classes []string) template.HTML {
+	attrs := attributesForField(fld, classes)
+	return fld.captcha.Render(prefixedNameForField(fld), attrs)
+}
+
+var captchaError = ErrorWrap(simpleError{code: CaptchaErrorCode, fr: CaptchaErrorMessageFr, en: CaptchaErrorMessageEn})
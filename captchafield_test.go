@@ -0,0 +1,76 @@
+package aform_test
+
+import (
+	"errors"
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"html/template"
+	"testing"
+)
+
+var errWrongAnswer = errors.New("Try again")
+
+// staticCaptcha is a CaptchaProvider whose answer is always "42".
+type staticCaptcha struct{}
+
+func (staticCaptcha) Render(name string, attrs map[string]string) template.HTML {
+	return template.HTML(`<input type="text" name="` + name + `" id="` + attrs["id"] + `">`)
+}
+
+func (staticCaptcha) Verify(values []string) bool {
+	return len(values) == 1 && values[0] == "42"
+}
+
+func TestCaptchaField(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		wantCode string
+	}{
+		{name: "right answer", values: []string{"42"}},
+		{name: "wrong answer", values: []string{"41"}, wantCode: aform.CaptchaErrorCode},
+		{name: "no answer", wantCode: aform.CaptchaErrorCode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			f := aform.Must(aform.New(
+				aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))),
+				aform.WithCaptchaField(aform.Must(aform.NewCaptchaField("Captcha", staticCaptcha{}))),
+			))
+			data := map[string][]string{"name": {"Jane"}}
+			if tt.values != nil {
+				data["captcha"] = tt.values
+			}
+			f.BindData(data)
+			a.Equal(tt.wantCode == "", f.IsValid())
+			a.Equal("Jane", f.CleanedData().Get("name"))
+			a.Empty(f.CleanedData()["captcha"])
+			if tt.wantCode != "" {
+				a.Equal(tt.wantCode, f.Errors().Get("captcha").Code())
+			}
+		})
+	}
+}
+
+func TestCaptchaField_AsDiv(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithCaptchaField(aform.Must(aform.NewCaptchaField("Captcha", staticCaptcha{})))))
+	f.BindData(map[string][]string{"captcha": {"41"}})
+	a.False(f.IsValid())
+	expected := `
+<div><label for="id_captcha">Captcha</label>
+<ul class="errorlist"><li id="err_0_id_captcha">Wrong answer, please try again</li></ul>
+<input type="text" name="captcha" id="id_captcha"></div>`
+	a.Equal(expected, string(f.AsDiv()))
+}
+
+func TestCaptchaField_CustomizeError(t *testing.T) {
+	a := assert.New(t)
+	fld := aform.Must(aform.NewCaptchaField("Captcha", staticCaptcha{}))
+	fld.CustomizeError(aform.ErrorWrapWithCode(errWrongAnswer, aform.CaptchaErrorCode))
+	f := aform.Must(aform.New(aform.WithCaptchaField(fld)))
+	f.BindData(map[string][]string{"captcha": {"41"}})
+	a.False(f.IsValid())
+	a.Equal(errWrongAnswer.Error(), f.Errors().Get("captcha").Error())
+}
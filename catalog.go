@@ -0,0 +1,389 @@
+package aform
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/go-playground/locales"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"golang.org/x/exp/slices"
+	"golang.org/x/text/language"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Messages maps an error code to its message template in one language.
+// Templates can contain the positional parameters of the error, like {0} in
+// FieldsEqualErrorMessageEn. A plural block selects a text with the plural
+// category of a parameter in the language of the messages, e.g.
+//
+//	{0, plural, one {# character} other {# characters}}
+//
+// Categories are zero, one, two, few, many and other, as defined by the CLDR
+// rules of the language. =N matches the parameter N exactly, e.g. =0 {no
+// character}. other is used when no other form matches. # is replaced by the
+// parameter. Messages rendered by Field.Errors can also contain named
+// placeholders like {label}, see Field.TranslateError. Messages are
+// registered with RegisterLanguage or RegisterMessages. They can be loaded
+// from JSON files with LoadJSONMessages or from gettext .po files with
+// LoadPOMessages.
+type Messages map[string]string
+
+// Codes of the validations done by go-playground validator. Their messages
+// are registered on the validator translators.
+var validatorErrorCodes = []string{BooleanErrorCode, EmailErrorCode, ChoiceErrorCode, MinLengthErrorCode, MaxLengthErrorCode, RequiredErrorCode, URLErrorCode}
+
+// validatorErrorMessagesEn are the messages used for the validations done by
+// go-playground validator when a registered language doesn't have them.
+var validatorErrorMessagesEn = Messages{
+	BooleanErrorCode:   BooleanErrorMessageEn,
+	EmailErrorCode:     EmailErrorMessageEn,
+	ChoiceErrorCode:    ChoiceErrorMessageEn,
+	MinLengthErrorCode: MinLengthErrorMessageEn,
+	MaxLengthErrorCode: MaxLengthErrorMessageEn,
+	RequiredErrorCode:  RequiredErrorMessageEn,
+	URLErrorCode:       URLErrorMessageEn,
+}
+
+var catalog = struct {
+	sync.RWMutex
+	messages map[language.Tag]Messages
+	// utLocales maps a language to the locale of its go-playground
+	// translator. e.g. "pt-BR" to "pt_BR".
+	utLocales map[string]string
+	// abandonedTranslators lists the locales of the go-playground
+	// translators added by a RegisterLanguage that failed. They can't be
+	// removed, they are replaced by the next registration.
+	abandonedTranslators map[string]bool
+}{
+	messages:             bundledMessages(),
+	utLocales:            map[string]string{},
+	abandonedTranslators: map[string]bool{},
+}
+
+// RegisterLanguage registers the language tag. Once registered, tag can be
+// given to WithLocales and is selected by BindRequest and BindData like the
+// bundled languages. translator is the go-playground locale of the language,
+// e.g. github.com/go-playground/locales/ja.New(). registerDefaults registers
+// the go-playground validator default translations, e.g.
+// github.com/go-playground/validator/v10/translations/ja.RegisterDefaultTranslations.
+// It can be nil if there are none. messages are the templates of aform error
+// codes, e.g. BooleanErrorCode or FieldsEqualErrorCode. Messages missing fall
+// back to English.
+//
+// RegisterLanguage should be called during the application initialization,
+// before any form is validated. It returns an error if tag is already
+// registered. If it fails, tag is not registered and RegisterLanguage can be
+// called again.
+func RegisterLanguage(tag language.Tag, translator locales.Translator, registerDefaults func(*validator.Validate, ut.Translator) error, messages Messages) error {
+	if translator == nil {
+		return fmt.Errorf("language %s must have a translator", tag)
+	}
+	if isRegisteredLanguage(tag) {
+		return fmt.Errorf("language %s is already registered", tag)
+	}
+	v := defaultValidate()
+	catalog.RLock()
+	override := catalog.abandonedTranslators[translator.Locale()]
+	catalog.RUnlock()
+	if err := universalTranslator.AddTranslator(translator, override); err != nil {
+		return err
+	}
+	trans, _ := universalTranslator.GetTranslator(translator.Locale())
+	if err := registerValidatorTranslations(v, trans, registerDefaults, messages); err != nil {
+		catalog.Lock()
+		catalog.abandonedTranslators[translator.Locale()] = true
+		catalog.Unlock()
+		return err
+	}
+	catalog.Lock()
+	delete(catalog.abandonedTranslators, translator.Locale())
+	catalog.utLocales[tag.String()] = translator.Locale()
+	languages = append(languages, tag)
+	catalog.Unlock()
+	storeMessages(tag, messages)
+	return nil
+}
+
+// registerValidatorTranslations registers on trans the go-playground
+// validator default translations and the messages of the validator error
+// codes. Codes missing in messages get the English message.
+func registerValidatorTranslations(v *validator.Validate, trans ut.Translator, registerDefaults func(*validator.Validate, ut.Translator) error, messages Messages) error {
+	if registerDefaults != nil {
+		if err := registerDefaults(v, trans); err != nil {
+			return err
+		}
+	}
+	for _, code := range validatorErrorCodes {
+		message, ok := messages[code]
+		if !ok {
+			message = validatorErrorMessagesEn[code]
+		}
+		if err := registerValidatorTranslation(v, trans, code, message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterMessages adds messages to tag. Existing messages with the same
+// codes are replaced, including built-in English and French messages. tag can
+// be a registered language or a regional variant of it. e.g. messages of
+// "fr-CA" override the French ones for the forms using the "fr-CA" locale,
+// other messages fall back to French. Keys of labels, help texts and choice
+// labels start with TextKeyPrefix. It returns an error if neither tag nor one
+// of its parents is registered.
+func RegisterMessages(tag language.Tag, messages Messages) error {
+	if !isSupportedLocale(tag) {
+		return fmt.Errorf("language %s is not registered", tag)
+	}
+	if isRegisteredLanguage(tag) {
+		v := defaultValidate()
+		trans, _ := universalTranslator.GetTranslator(translatorLocale(tag.String()))
+		for code, message := range messages {
+			if slices.Contains(validatorErrorCodes, code) {
+				if err := registerValidatorTranslation(v, trans, code, message); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	storeMessages(tag, messages)
+	return nil
+}
+
+// TextKeyPrefix is the prefix of the message keys of labels, help texts and
+// choice labels in the messages registered with RegisterMessages. It keeps
+// them apart from error codes. The key given to WithLabelKey, WithHelpTextKey
+// or ChoiceFieldOption.LabelKey is looked up with this prefix. e.g.
+//
+//	RegisterMessages(language.French, Messages{TextKeyPrefix + "first_name": "Prénom"})
+//	WithLabelKey("first_name")
+const TextKeyPrefix = "text."
+
+// storeMessages adds messages to the catalog of tag.
+func storeMessages(tag language.Tag, messages Messages) {
+	catalog.Lock()
+	defer catalog.Unlock()
+	if catalog.messages[tag] == nil {
+		catalog.messages[tag] = Messages{}
+	}
+	for code, message := range messages {
+		catalog.messages[tag][code] = message
+	}
+}
+
+func registerValidatorTranslation(v *validator.Validate, trans ut.Translator, code, message string) error {
+	return v.RegisterTranslation(code, trans, func(ut ut.Translator) error {
+		return nil
+	}, func(ut ut.Translator, fe validator.FieldError) string {
+		return formatMessage(ut, message, []string{fe.Param()})
+	})
+}
+
+func isRegisteredLanguage(tag language.Tag) bool {
+	catalog.RLock()
+	defer catalog.RUnlock()
+	for _, l := range languages {
+		if l == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// translatorLocale returns the locale of the go-playground translator of the
+// language locale.
+func translatorLocale(locale string) string {
+	catalog.RLock()
+	defer catalog.RUnlock()
+	if utLocale, ok := catalog.utLocales[locale]; ok {
+		return utLocale
+	}
+	return locale
+}
+
+// localeTranslator returns the go-playground translator of the first locale
+// of the fallback chain of locale having one.
+func localeTranslator(locale language.Tag) locales.Translator {
+	defaultValidate()
+	for _, tag := range localeChain(locale.String()) {
+		if trans, found := universalTranslator.GetTranslator(translatorLocale(tag.String())); found {
+			return trans
+		}
+	}
+	return universalTranslator.GetFallback()
+}
+
+// isSupportedLocale returns true if tag or one of its parents is a
+// registered language.
+func isSupportedLocale(tag language.Tag) bool {
+	for _, parent := range localeParents(tag) {
+		if isRegisteredLanguage(parent) {
+			return true
+		}
+	}
+	return false
+}
+
+// localeParents returns tag followed by its parents. e.g. "es-MX", "es-419"
+// and "es".
+func localeParents(tag language.Tag) []language.Tag {
+	var parents []language.Tag
+	for ; tag != language.Und; tag = tag.Parent() {
+		parents = append(parents, tag)
+	}
+	return parents
+}
+
+// localeChain returns the fallback chain of locale used to translate
+// messages: locale, its parents and finally the default language. e.g.
+// "fr-CA", "fr" and "en".
+func localeChain(locale string) []language.Tag {
+	var chain []language.Tag
+	if tag, err := language.Parse(locale); err == nil {
+		chain = localeParents(tag)
+	}
+	for _, tag := range chain {
+		if tag == defaultLanguage {
+			return chain
+		}
+	}
+	return append(chain, defaultLanguage)
+}
+
+// translateMessage returns the message of code for the first locale of the
+// fallback chain of locale having one. For each locale of the chain, messages
+// registered with RegisterLanguage or RegisterMessages are looked up first
+// and builtin second. params fill the messages found with formatMessage.
+func translateMessage(locale, code string, params []string, builtin func(tag language.Tag) (string, bool)) string {
+	for _, tag := range localeChain(locale) {
+		if message, ok := lookupMessage(tag, code); ok {
+			return formatMessage(localeTranslator(tag), message, params)
+		}
+		if message, ok := builtin(tag); ok {
+			return formatMessage(localeTranslator(tag), message, params)
+		}
+	}
+	return ""
+}
+
+// lookupText returns the text of key registered for tag with TextKeyPrefix.
+func lookupText(tag language.Tag, key string) (string, bool) {
+	return lookupMessage(tag, TextKeyPrefix+key)
+}
+
+// lookupMessage returns the message of code registered for tag with
+// RegisterLanguage or RegisterMessages.
+func lookupMessage(tag language.Tag, code string) (string, bool) {
+	catalog.RLock()
+	defer catalog.RUnlock()
+	message, ok := catalog.messages[tag][code]
+	return message, ok
+}
+
+// LoadJSONMessages loads the file name from fsys. The file must be a JSON
+// object mapping error codes to messages. e.g.
+//
+//	{"required": "Dieses Feld ist erforderlich"}
+func LoadJSONMessages(fsys fs.FS, name string) (Messages, error) {
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	messages := Messages{}
+	if err := json.Unmarshal(b, &messages); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return messages, nil
+}
+
+// LoadPOMessages loads the gettext file name from fsys. msgid is the error
+// code and msgstr its message. The header, entries marked as fuzzy and
+// entries without translation are ignored. e.g.
+//
+//	msgid "required"
+//	msgstr "Dieses Feld ist erforderlich"
+func LoadPOMessages(fsys fs.FS, name string) (Messages, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	messages, err := parsePO(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return messages, nil
+}
+
+func parsePO(r io.Reader) (Messages, error) {
+	messages := Messages{}
+	var id, str, ignored string
+	var current *string
+	// translated is true once a msgstr is read. The next msgctxt, msgid or
+	// comment starts a new entry.
+	translated, fuzzy := false, false
+	flush := func() {
+		if len(id) > 0 && len(str) > 0 && !fuzzy {
+			messages[id] = str
+		}
+		id, str, current = "", "", nil
+		translated, fuzzy = false, false
+	}
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		keyword, rest, _ := strings.Cut(line, " ")
+		switch {
+		case len(line) == 0:
+			flush()
+			continue
+		case strings.HasPrefix(line, "#"):
+			if translated {
+				flush()
+			}
+			if strings.HasPrefix(line, "#,") && strings.Contains(line, "fuzzy") {
+				fuzzy = true
+			}
+			continue
+		case keyword == "msgctxt" || keyword == "msgid":
+			if translated {
+				flush()
+			}
+			current = &ignored
+			if keyword == "msgid" {
+				current = &id
+			}
+		case keyword == "msgid_plural":
+			current = &ignored
+		case keyword == "msgstr" || keyword == "msgstr[0]":
+			current = &str
+			translated = true
+		case strings.HasPrefix(keyword, "msgstr["):
+			current = &ignored
+			translated = true
+		case strings.HasPrefix(line, `"`):
+			rest = line
+		default:
+			return nil, fmt.Errorf("line %d: unexpected %q", lineNumber, line)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: string without keyword", lineNumber)
+		}
+		s, err := strconv.Unquote(rest)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid string %s", lineNumber, rest)
+		}
+		*current += s
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return messages, nil
+}
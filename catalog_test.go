@@ -0,0 +1,279 @@
+package aform_test
+
+import (
+	"errors"
+	"github.com/go-playground/locales/ja"
+	"github.com/go-playground/locales/ko"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	ja_translations "github.com/go-playground/validator/v10/translations/ja"
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"testing"
+	"testing/fstest"
+)
+
+var catalogFS = fstest.MapFS{
+	"ja.json": {Data: []byte(`{
+	"required": "この項目は必須です",
+	"min": "{0}文字以上で入力してください",
+	"fields_equal": "{0}と一致させてください"
+}`)},
+	"ja.po": {Data: []byte(`# Japanese translations
+msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+
+msgid "email"
+msgstr "有効なメールアドレスを"
+"入力してください"
+
+#, fuzzy
+msgid "url"
+msgstr "URL"
+
+msgctxt "validator"
+msgid "integer"
+msgstr "整数を入力してください"
+msgid "captcha"
+msgstr ""
+`)},
+	"invalid.json": {Data: []byte(`["required"]`)},
+	"invalid.po":   {Data: []byte(`msgid required`)},
+}
+
+// registerJapanese registers Japanese with the messages of catalogFS until
+// the end of the test t.
+func registerJapanese(t *testing.T) {
+	t.Helper()
+	messages, err := aform.LoadJSONMessages(catalogFS, "ja.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	poMessages, err := aform.LoadPOMessages(catalogFS, "ja.po")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for code, message := range poMessages {
+		messages[code] = message
+	}
+	if err := aform.RegisterLanguage(language.Japanese, ja.New(), ja_translations.RegisterDefaultTranslations, messages); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		aform.ExportUnregisterLanguage(language.Japanese)
+	})
+}
+
+func TestLoadJSONMessages(t *testing.T) {
+	a := assert.New(t)
+	messages, err := aform.LoadJSONMessages(catalogFS, "ja.json")
+	a.NoError(err)
+	a.Equal(aform.Messages{
+		aform.RequiredErrorCode:    "この項目は必須です",
+		aform.MinLengthErrorCode:   "{0}文字以上で入力してください",
+		aform.FieldsEqualErrorCode: "{0}と一致させてください",
+	}, messages)
+	_, err = aform.LoadJSONMessages(catalogFS, "invalid.json")
+	a.Error(err)
+	_, err = aform.LoadJSONMessages(catalogFS, "missing.json")
+	a.Error(err)
+}
+
+func TestLoadPOMessages(t *testing.T) {
+	a := assert.New(t)
+	messages, err := aform.LoadPOMessages(catalogFS, "ja.po")
+	a.NoError(err)
+	a.Equal(aform.Messages{
+		aform.EmailErrorCode:   "有効なメールアドレスを入力してください",
+		aform.IntegerErrorCode: "整数を入力してください",
+	}, messages)
+	_, err = aform.LoadPOMessages(catalogFS, "invalid.po")
+	a.Error(err)
+	_, err = aform.LoadPOMessages(catalogFS, "missing.po")
+	a.Error(err)
+}
+
+func TestRegisterLanguage(t *testing.T) {
+	a := assert.New(t)
+	registerJapanese(t)
+	a.Error(aform.RegisterLanguage(language.Japanese, ja.New(), nil, nil), "already registered")
+	a.Error(aform.RegisterLanguage(language.Korean, nil, nil, nil), "no translator")
+	a.Error(aform.RegisterMessages(language.Korean, aform.Messages{}), "not registered")
+	a.True(aform.ExportIsRegisteredLanguage(language.Japanese))
+	a.Equal(language.Japanese, aform.ExportSelectLanguage([]language.Tag{language.English, language.Japanese}, "ja"))
+}
+
+func TestRegisterLanguage_form(t *testing.T) {
+	registerJapanese(t)
+	tests := []struct {
+		name string
+		data map[string][]string
+		want map[string]string
+	}{
+		{
+			name: "validator messages",
+			data: map[string][]string{"email": {"jane"}, "password": {"ab"}, "confirmation": {"ab"}, "website": {"example.com"}, "age": {"x"}},
+			want: map[string]string{
+				"email":    "有効なメールアドレスを入力してください",
+				"password": "3文字以上で入力してください",
+				"age":      "整数を入力してください",
+			},
+		},
+		{
+			name: "rule messages",
+			data: map[string][]string{"email": {"jane@example.com"}, "password": {"abcd"}, "confirmation": {"abce"}, "website": {""}, "age": {"42"}},
+			want: map[string]string{
+				"confirmation": "Passwordと一致させてください",
+				"website":      "この項目は必須です",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			f := aform.Must(aform.New(
+				aform.WithLocales([]language.Tag{language.English, language.Japanese}),
+				aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))),
+				aform.WithCharField(aform.Must(aform.NewCharField("Password", "", "", 3, 0))),
+				aform.WithCharField(aform.Must(aform.NewCharField("Confirmation", "", "", 0, 0))),
+				aform.WithCharField(aform.Must(aform.DefaultCharField("Website"))),
+				aform.WithCharField(aform.Must(aform.DefaultCharField("Age", aform.WithValidators(aform.Integer())))),
+				aform.FieldsEqual("Password", "Confirmation"),
+			))
+			f.BindData(tt.data, "ja")
+			a.False(f.IsValid())
+			got := map[string]string{}
+			for name, errs := range f.Errors() {
+				got[name] = errs[0].Translate("ja")
+			}
+			a.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestRegisterLanguage_retryAfterError(t *testing.T) {
+	a := assert.New(t)
+	errDefaults := errors.New("defaults failed")
+	failingDefaults := func(*validator.Validate, ut.Translator) error {
+		return errDefaults
+	}
+	a.ErrorIs(aform.RegisterLanguage(language.Korean, ko.New(), failingDefaults, nil), errDefaults)
+	a.False(aform.ExportIsRegisteredLanguage(language.Korean))
+	a.Error(aform.RegisterMessages(language.Korean, aform.Messages{}))
+	a.NoError(aform.RegisterLanguage(language.Korean, ko.New(), nil, aform.Messages{aform.RequiredErrorCode: "필수 항목입니다"}))
+	t.Cleanup(func() {
+		aform.ExportUnregisterLanguage(language.Korean)
+	})
+	a.True(aform.ExportIsRegisteredLanguage(language.Korean))
+	f := aform.Must(aform.New(
+		aform.WithLocales([]language.Tag{language.English, language.Korean}),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))),
+	))
+	f.BindData(map[string][]string{}, "ko")
+	a.False(f.IsValid())
+	a.Equal("필수 항목입니다", f.Errors().Get("name").Translate("ko"))
+}
+
+func TestRegisterMessages_textKeys(t *testing.T) {
+	a := assert.New(t)
+	a.NoError(aform.RegisterMessages(language.English, aform.Messages{aform.TextKeyPrefix + aform.RequiredErrorCode: "Mandatory"}))
+	t.Cleanup(func() {
+		aform.ExportDeleteMessages(language.English, aform.TextKeyPrefix+aform.RequiredErrorCode)
+	})
+	f := aform.Must(aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("Name", aform.WithLabelKey(aform.RequiredErrorCode))))))
+	f.BindData(map[string][]string{})
+	a.False(f.IsValid())
+	a.Equal(aform.RequiredErrorMessageEn, f.Errors().Get("name").Translate("en"))
+	a.Contains(string(f.AsDiv()), `<label for="id_name">Mandatory</label>`)
+	a.EqualError(aform.RegisterErrorCode(aform.TextKeyPrefix+"taken", aform.ErrorMessages{language.English: "Taken"}), "error code text.taken can't start with text.")
+}
+
+func TestRegisterMessages_override(t *testing.T) {
+	a := assert.New(t)
+	registerJapanese(t)
+	a.NoError(aform.RegisterMessages(language.Japanese, aform.Messages{aform.CaptchaErrorCode: "もう一度お試しください"}))
+	fld := aform.Must(aform.NewCaptchaField("Captcha", staticCaptcha{}))
+	_, errs := fld.Clean([]string{"0"})
+	if a.Len(errs, 1) {
+		a.Equal("もう一度お試しください", errs[0].Translate("ja"))
+		a.Equal(aform.CaptchaErrorMessageFr, errs[0].Translate("fr"))
+	}
+}
+
+func TestExportLocaleChain(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   []string
+	}{
+		{locale: "fr-CA", want: []string{"fr-CA", "fr", "en"}},
+		{locale: "fr", want: []string{"fr", "en"}},
+		{locale: "es-MX", want: []string{"es-MX", "es-419", "es", "en"}},
+		{locale: "en-GB", want: []string{"en-GB", "en-001", "en"}},
+		{locale: "en", want: []string{"en"}},
+		{locale: "", want: []string{"en"}},
+		{locale: "not a locale", want: []string{"en"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			var got []string
+			for _, tag := range aform.ExportLocaleChain(tt.locale) {
+				got = append(got, tag.String())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLocaleFallback(t *testing.T) {
+	a := assert.New(t)
+	registerErrorCode(t, multipleOfErrorCode, multipleOfMessages)
+	f := aform.Must(aform.New(
+		aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))),
+		aform.WithBooleanField(aform.Must(aform.DefaultBooleanField("Terms"))),
+	))
+	f.BindData(map[string][]string{"email": {"jane"}})
+	a.False(f.IsValid())
+	email, terms := f.Errors()["email"][0], f.Errors()["terms"][0]
+	a.Equal(aform.EmailErrorMessageFr, email.Translate("fr-CA"))
+	a.Equal(aform.RequiredErrorMessageFr, terms.Translate("fr-CA"))
+	a.Equal(aform.EmailErrorMessagePt, email.Translate("pt-BR"))
+	a.Equal(aform.RequiredErrorMessagePt, terms.Translate("pt-BR"))
+	a.Equal(aform.EmailErrorMessageEn, email.Translate("en-GB"))
+	a.Equal(aform.RequiredErrorMessageEn, terms.Translate("en-GB"))
+	a.Equal(aform.EmailErrorMessageEn, email.Translate("da-DK"))
+	a.Equal(aform.RequiredErrorMessageEn, terms.Translate("da-DK"))
+	a.Equal("Assurez-vous que cette valeur est un multiple de 5", aform.NewError(multipleOfErrorCode, "5").Translate("fr-CA"))
+}
+
+func TestRegisterMessages_region(t *testing.T) {
+	a := assert.New(t)
+	frBE := language.MustParse("fr-BE")
+	a.NoError(aform.RegisterMessages(frBE, aform.Messages{
+		aform.RequiredErrorCode: "Ce champ est requis",
+		aform.EmailErrorCode:    "Encodez une adresse e-mail valide",
+	}))
+	t.Cleanup(func() {
+		aform.ExportDeleteMessages(frBE, aform.RequiredErrorCode, aform.EmailErrorCode)
+	})
+	a.Error(aform.RegisterMessages(language.MustParse("ko-KR"), aform.Messages{}))
+	f := aform.Must(aform.New(
+		aform.WithLocales([]language.Tag{language.English, language.French, frBE}),
+		aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))),
+		aform.WithCharField(aform.Must(aform.NewCharField("Password", "", "", 8, 0))),
+		aform.WithBooleanField(aform.Must(aform.DefaultBooleanField("Terms"))),
+	))
+	f.BindData(map[string][]string{"email": {"jane"}, "password": {"secret"}}, "fr-BE")
+	a.False(f.IsValid())
+	got := map[string]string{}
+	for name, errs := range f.Errors() {
+		got[name] = errs[0].Translate("fr-BE")
+	}
+	a.Equal(map[string]string{
+		"email":    "Encodez une adresse e-mail valide",
+		"password": "Assurez-vous que cette valeur fait au minimum 8 caractères",
+		"terms":    "Ce champ est requis",
+	}, got)
+	a.Equal(aform.RequiredErrorMessageFr, f.Errors()["terms"][0].Translate("fr"))
+}
@@ -66,10 +66,31 @@ func (fld *CharField) Clean(value string) (string, []Error) {
 	if fld.notRequired && len(sanitizedValue) == 0 {
 		return fld.EmptyValue(), nil
 	}
-	fld.errors = customizeErrors(fld.validateFunc(sanitizedValue, !fld.notRequired), fld.customErrors)
+	if fld.localized != nil && len(sanitizedValue) > 0 {
+		canonical, errs := fld.delocalize(sanitizedValue)
+		if len(errs) > 0 {
+			fld.errors = customizeErrors(errs, fld.customErrors)
+			return sanitizedValue, fld.errors
+		}
+		sanitizedValue = canonical
+	}
+	fld.errors = customizeErrors(fld.validate(sanitizedValue, !fld.notRequired), fld.customErrors)
 	return sanitizedValue, fld.errors
 }
 
+// hasChanged returns true if the first sanitized value is different from
+// the initial value. Localized values are compared in their canonical
+// representation.
+func (fld *CharField) hasChanged(values []string) bool {
+	value := fld.sanitize(firstValue(values))
+	if fld.localized != nil {
+		if canonical, ok := fld.localized.parse(value, fld.locale); ok {
+			value = canonical
+		}
+	}
+	return value != fld.initialValue
+}
+
 // EmptyValue returns the CharField empty value. The empty value is the
 // cleaned value returned by Clean when there is no data bound to the field.
 // To set a custom empty value use NewCharField.
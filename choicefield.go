@@ -60,10 +60,16 @@ func (fld *ChoiceField) Clean(value string) (string, []Error) {
 	if fld.notRequired && len(sanitizedValue) == 0 {
 		return fld.EmptyValue(), nil
 	}
-	fld.errors = customizeErrors(fld.validateFunc(sanitizedValue, !fld.notRequired), fld.customErrors)
+	fld.errors = customizeErrors(fld.validate(sanitizedValue, !fld.notRequired), fld.customErrors)
 	return sanitizedValue, fld.errors
 }
 
+// hasChanged returns true if the first sanitized value is different from
+// the initial value.
+func (fld *ChoiceField) hasChanged(values []string) bool {
+	return fld.sanitize(firstValue(values)) != fld.initialValue
+}
+
 // EmptyValue returns the ChoiceField empty value. The empty value is the
 // cleaned value returned by Clean when there is no data bound to the field.
 // A ChoiceField empty value is always the empty string "".
@@ -2,13 +2,14 @@ package aform
 
 import (
 	"golang.org/x/exp/slices"
+	"golang.org/x/text/language"
 )
 
-func fieldGroupsToWidgetGroups(groups []choiceFieldOptionGroup, optionWidget Widget, baseID, htmlName string, selected []string) []map[string][]widgetOption {
+func fieldGroupsToWidgetGroups(groups []choiceFieldOptionGroup, optionWidget Widget, baseID, htmlName string, selected []string, locale language.Tag) []map[string][]widgetOption {
 	output := make([]map[string][]widgetOption, 0, len(groups))
 	groupIndex := uint(0)
 	for _, group := range groups {
-		groupWidget := group.widget(optionWidget, baseID, htmlName, groupIndex, selected)
+		groupWidget := group.widget(optionWidget, baseID, htmlName, groupIndex, selected, locale)
 		groupIndex += indexesUsedByGroup(groupWidget)
 		output = append(output, groupWidget)
 	}
@@ -29,9 +30,17 @@ func indexesUsedByGroup(group map[string][]widgetOption) uint {
 
 type choiceFieldOptionGroup map[string][]ChoiceFieldOption
 
+// ChoiceFieldOption is an option of a field presenting a list of choices.
+// Label is the text displayed for the option. LabelT translates it in several
+// languages and LabelKey looks it up in the messages registered with
+// RegisterMessages. They are resolved with the locale of the field like
+// Field.SetLabelT and Field.SetLabelKey. Label is used when they can't be
+// resolved.
 type ChoiceFieldOption struct {
-	Value string
-	Label string
+	Value    string
+	Label    string
+	LabelT   map[language.Tag]string
+	LabelKey string
 }
 
 func (g choiceFieldOptionGroup) values() []string {
@@ -44,7 +53,7 @@ func (g choiceFieldOptionGroup) values() []string {
 	return values
 }
 
-func (g choiceFieldOptionGroup) widget(inputWidget Widget, baseID, htmlName string, groupIndex uint, selected []string) map[string][]widgetOption {
+func (g choiceFieldOptionGroup) widget(inputWidget Widget, baseID, htmlName string, groupIndex uint, selected []string, locale language.Tag) map[string][]widgetOption {
 	output := map[string][]widgetOption{}
 	for groupName, groupOptions := range g {
 		widgetOptions := make([]widgetOption, len(groupOptions))
@@ -59,19 +68,19 @@ func (g choiceFieldOptionGroup) widget(inputWidget Widget, baseID, htmlName stri
 					attrs["id"] = normalizedGroupID(baseID, groupIndex+uint(i))
 				}
 			}
-			widgetOptions[i] = option.widget(inputWidget, htmlName, slices.Contains(selected, option.Value), attrs)
+			widgetOptions[i] = option.widget(inputWidget, htmlName, slices.Contains(selected, option.Value), attrs, locale)
 		}
 		output[groupName] = widgetOptions
 	}
 	return output
 }
 
-func (o ChoiceFieldOption) widget(inputWidget Widget, htmlName string, selected bool, attrs map[string]string) widgetOption {
+func (o ChoiceFieldOption) widget(inputWidget Widget, htmlName string, selected bool, attrs map[string]string, locale language.Tag) widgetOption {
 	if selectedAttr, ok := inputWidget.selectedAttr(selected); ok {
 		attrs[selectedAttr.n] = selectedAttr.v
 	}
 	return widgetOption{
-		Label:       o.Label,
+		Label:       localizedText{translations: o.LabelT, key: o.LabelKey}.resolve(locale, o.Label),
 		WrapLabel:   true,
 		widgetInput: widgetInput{Type: inputWidget, Name: htmlName, Value: o.Value, Attrs: attrs},
 	}
@@ -0,0 +1,138 @@
+package aform
+
+import (
+	"context"
+	"crypto/hmac"
+	"fmt"
+	"net/http"
+)
+
+// Names of the cookie and of the hidden field used by the CSRF protection.
+// See WithCSRF and CSRFMiddleware.
+const (
+	CSRFCookieName = "csrftoken"
+	CSRFFieldName  = "csrf_token"
+)
+
+// CSRFErrorCode is the error code of the error added when the CSRF check
+// fails. See WithCSRF.
+const CSRFErrorCode = "csrf"
+
+// English and French error messages of the failed CSRF check.
+const (
+	CSRFErrorMessageEn = "CSRF verification failed. Reload the page and submit the form again"
+	CSRFErrorMessageFr = "La vérification CSRF a échoué. Rechargez la page et soumettez à nouveau le formulaire"
+)
+
+const csrfTokenBytes = 32
+
+type csrfContextKey struct{}
+
+// CSRFMiddleware returns an http.Handler issuing the CSRF token checked by
+// the forms created with WithCSRF. If req has no valid token in the cookie
+// named CSRFCookieName, a random token is generated and set in the cookie.
+// The token is also added to the request context given to next, so forms can
+// render it before the browser gets the cookie.
+func CSRFMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if token := csrfCookieToken(req); len(token) > 0 {
+			next.ServeHTTP(w, req)
+			return
+		}
+		token := randomToken(csrfTokenBytes)
+		http.SetCookie(w, &http.Cookie{
+			Name:     CSRFCookieName,
+			Value:    token,
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   req.TLS != nil,
+			SameSite: http.SameSiteLaxMode,
+		})
+		next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), csrfContextKey{}, token)))
+	})
+}
+
+// WithCSRF returns a FormOption that protects the form against Cross-Site
+// Request Forgery. The token issued by CSRFMiddleware is rendered by AsDiv
+// in a hidden field named CSRFFieldName. Its value is an HMAC of the token
+// computed with secret. During validation, the submitted value must match
+// the token of the request cookie. Otherwise, an error with the code
+// CSRFErrorCode is added to the non-field errors.
+//
+// The form must know the request to render and check the token: use
+// BindRequest to bind it and SetRequest to render an unbound form. A form
+// bound with BindData only is never valid.
+func WithCSRF(secret []byte) FormOption {
+	return func(f *Form) error {
+		if len(secret) == 0 {
+			return fmt.Errorf("CSRF secret can't be empty")
+		}
+		f.csrfSecret = secret
+		return nil
+	}
+}
+
+// SetRequest sets the request the form is rendered for. It is required to
+// render an unbound form created with WithCSRF. BindRequest sets it too. The
+// locale of an unbound form is selected with the resolvers set with
+// WithLocaleResolvers. See SelectLocale.
+func (f *Form) SetRequest(req *http.Request) {
+	f.req = req
+	if req != nil && !f.bound {
+		f.SelectLocale(resolveLocales(req, f.localeResolvers)...)
+	}
+}
+
+func (f *Form) csrfEnabled() bool {
+	return len(f.csrfSecret) > 0
+}
+
+func (f *Form) csrfFieldName() string {
+	return prefixedName(f.prefix, CSRFFieldName)
+}
+
+// csrfSigningPurpose is the purpose of the HMAC of the CSRF token rendered
+// in the hidden field. See signature.
+const csrfSigningPurpose = "aform.csrf:"
+
+// csrfValue returns the value of the hidden field for the token of the
+// request, or the empty string if there is no request or no token.
+func (f *Form) csrfValue() string {
+	if f.req == nil {
+		return ""
+	}
+	token := csrfToken(f.req)
+	if len(token) == 0 {
+		return ""
+	}
+	return signature(f.csrfSecret, csrfSigningPurpose, token)
+}
+
+// csrfErrors returns nil if the CSRF protection is disabled or if the bound
+// value matches the token of the request.
+func (f *Form) csrfErrors() []Error {
+	if !f.csrfEnabled() {
+		return nil
+	}
+	expected := f.csrfValue()
+	if len(expected) > 0 && hmac.Equal([]byte(f.protectionData[f.csrfFieldName()]), []byte(expected)) {
+		return nil
+	}
+	return []Error{ErrorWrap(simpleError{code: CSRFErrorCode, fr: CSRFErrorMessageFr, en: CSRFErrorMessageEn})}
+}
+
+// csrfToken returns the token issued by CSRFMiddleware for req.
+func csrfToken(req *http.Request) string {
+	if token, ok := req.Context().Value(csrfContextKey{}).(string); ok {
+		return token
+	}
+	return csrfCookieToken(req)
+}
+
+func csrfCookieToken(req *http.Request) string {
+	cookie, err := req.Cookie(CSRFCookieName)
+	if err != nil || len(cookie.Value) != 2*csrfTokenBytes {
+		return ""
+	}
+	return cookie.Value
+}
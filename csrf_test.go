@@ -0,0 +1,184 @@
+package aform_test
+
+import (
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var csrfSecret = []byte("csrf secret")
+
+// issueCSRFToken runs a request through CSRFMiddleware and returns the
+// cookie set by the middleware and the form rendered by the handler.
+func issueCSRFToken(t *testing.T) (*http.Cookie, string) {
+	var rendered string
+	handler := aform.CSRFMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		f := aform.Must(aform.New(aform.WithCSRF(csrfSecret), aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+		f.SetRequest(req)
+		rendered = string(f.AsDiv())
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != aform.CSRFCookieName {
+		t.Fatalf("expected a single %s cookie, got %v", aform.CSRFCookieName, cookies)
+	}
+	return cookies[0], rendered
+}
+
+func postCSRFForm(cookie *http.Cookie, values url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(values.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	return req
+}
+
+func TestCSRFMiddleware(t *testing.T) {
+	a := assert.New(t)
+	cookie, rendered := issueCSRFToken(t)
+	a.Len(cookie.Value, 64)
+	a.True(cookie.HttpOnly)
+	expected := `
+<input type="hidden" name="csrf_token" value="` + aform.ExportSignature(csrfSecret, aform.ExportCSRFSigningPurpose, cookie.Value) + `">
+<div><label for="id_name">Name</label><input type="text" name="name" id="id_name" maxlength="256" required></div>`
+	a.Equal(expected, rendered)
+	// A request with a valid cookie keeps its token.
+	handler := aform.CSRFMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookie)
+	handler.ServeHTTP(rec, req)
+	a.Empty(rec.Result().Cookies())
+}
+
+func TestWithCSRF(t *testing.T) {
+	cookie, _ := issueCSRFToken(t)
+	otherCookie, _ := issueCSRFToken(t)
+	validToken := aform.ExportSignature(csrfSecret, aform.ExportCSRFSigningPurpose, cookie.Value)
+	tests := []struct {
+		name   string
+		form   *aform.Form
+		cookie *http.Cookie
+		values url.Values
+		want   bool
+	}{
+		{
+			name:   "valid token",
+			form:   aform.Must(aform.New(aform.WithCSRF(csrfSecret), aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))))),
+			cookie: cookie,
+			values: url.Values{"name": {"Jane"}, aform.CSRFFieldName: {validToken}},
+			want:   true,
+		},
+		{
+			name:   "valid token with prefix",
+			form:   aform.Must(aform.New(aform.WithPrefix("p"), aform.WithCSRF(csrfSecret), aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))))),
+			cookie: cookie,
+			values: url.Values{"p-name": {"Jane"}, "p-" + aform.CSRFFieldName: {validToken}},
+			want:   true,
+		},
+		{
+			name:   "missing token",
+			form:   aform.Must(aform.New(aform.WithCSRF(csrfSecret), aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))))),
+			cookie: cookie,
+			values: url.Values{"name": {"Jane"}},
+		},
+		{
+			name:   "missing cookie",
+			form:   aform.Must(aform.New(aform.WithCSRF(csrfSecret), aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))))),
+			values: url.Values{"name": {"Jane"}, aform.CSRFFieldName: {validToken}},
+		},
+		{
+			name:   "token of another cookie",
+			form:   aform.Must(aform.New(aform.WithCSRF(csrfSecret), aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))))),
+			cookie: otherCookie,
+			values: url.Values{"name": {"Jane"}, aform.CSRFFieldName: {validToken}},
+		},
+		{
+			name:   "token signed with another secret",
+			form:   aform.Must(aform.New(aform.WithCSRF(csrfSecret), aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))))),
+			cookie: cookie,
+			values: url.Values{"name": {"Jane"}, aform.CSRFFieldName: {aform.ExportSignature([]byte("other"), aform.ExportCSRFSigningPurpose, cookie.Value)}},
+		},
+		{
+			name:   "strict binding accepts the token field",
+			form:   aform.Must(aform.New(aform.WithStrictBinding(), aform.WithCSRF(csrfSecret), aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))))),
+			cookie: cookie,
+			values: url.Values{"name": {"Jane"}, aform.CSRFFieldName: {validToken}},
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			a.NoError(tt.form.BindRequest(postCSRFForm(tt.cookie, tt.values)))
+			a.Equal(tt.want, tt.form.IsValid())
+			a.Equal("Jane", tt.form.CleanedData().Get("name"))
+			a.False(tt.form.CleanedData().Has(aform.CSRFFieldName))
+			if tt.want {
+				a.Empty(tt.form.NonFieldErrors())
+				return
+			}
+			if a.Len(tt.form.NonFieldErrors(), 1) {
+				a.Equal(aform.CSRFErrorCode, tt.form.NonFieldErrors()[0].Code())
+			}
+		})
+	}
+}
+
+func TestWithCSRF_BindData(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithCSRF(csrfSecret), aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+	f.BindData(map[string][]string{"name": {"Jane"}})
+	a.False(f.IsValid())
+	expected := `
+<ul class="errorlist nonfield"><li>CSRF verification failed. Reload the page and submit the form again</li></ul>
+<input type="hidden" name="csrf_token">
+<div><label for="id_name">Name</label><input type="text" name="name" value="Jane" id="id_name" maxlength="256" required></div>`
+	a.Equal(expected, string(f.AsDiv()))
+}
+
+func TestWithCSRF_FormSet(t *testing.T) {
+	cookie, _ := issueCSRFToken(t)
+	validToken := aform.ExportSignature(csrfSecret, aform.ExportCSRFSigningPurpose, cookie.Value)
+	newFormSet := func() *aform.FormSet {
+		return aform.Must(aform.NewFormSet(func(index int) (*aform.Form, error) {
+			return aform.New(aform.WithCSRF(csrfSecret), aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))))
+		}))
+	}
+	values := url.Values{
+		"form-total_forms":              {"2"},
+		"form-initial_forms":            {"0"},
+		"form-0-name":                   {"Jane"},
+		"form-0-" + aform.CSRFFieldName: {validToken},
+		"form-1-name":                   {"John"},
+		"form-1-" + aform.CSRFFieldName: {validToken},
+	}
+	a := assert.New(t)
+	fs := newFormSet()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookie)
+	fs.SetRequest(req)
+	a.Contains(string(fs.AsDiv()), `<input type="hidden" name="form-0-csrf_token" value="`+validToken+`">`)
+	fs = newFormSet()
+	a.NoError(fs.BindRequest(postCSRFForm(cookie, values)))
+	a.True(fs.IsValid())
+	values.Del("form-1-" + aform.CSRFFieldName)
+	fs = newFormSet()
+	a.NoError(fs.BindRequest(postCSRFForm(cookie, values)))
+	a.False(fs.IsValid())
+	a.Empty(fs.Forms()[0].NonFieldErrors())
+	if a.Len(fs.Forms()[1].NonFieldErrors(), 1) {
+		a.Equal(aform.CSRFErrorCode, fs.Forms()[1].NonFieldErrors()[0].Code())
+	}
+}
+
+func TestWithCSRF_emptySecret(t *testing.T) {
+	_, err := aform.New(aform.WithCSRF(nil))
+	assert.Error(t, err)
+}
@@ -64,7 +64,10 @@ published:
 		// if this is a POST request we need to process the form data
 		if req.Method == "POST" {
 			// Populate the form with data from the request:
-			nameForm.BindRequest(req)
+			if err := nameForm.BindRequest(req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
 			// check whether it's valid:
 			if nameForm.IsValid() {
 				// process the data with Form.CleanedData
@@ -84,6 +87,9 @@ If the form is submitted using a POST request, the handler will populate the
 form with data from the request:
 	nameForm.BindRequest(req)
 This is called “binding data to the form” (it is now a bound form).
+Form.BindRequest parses the request body itself. If the body can't be parsed,
+for instance because it is too large, it returns an error and the form is not
+bound.
 
 We call the Form.IsValid method; if it’s not True, we go back to the template
 with the form. This time the form is no longer empty (unbound) so the HTML form
@@ -102,7 +108,6 @@ We don’t need to do much in our template:
 */
 //	<form action="/your-name" method="post">
 //		{{ .form.AsDiv }}
-//		{{/* Add CSRF */}}
 //		<button type="submit">OK</button>
 //	</form>
 /*
@@ -110,6 +115,11 @@ We don’t need to do much in our template:
 All the form’s fields and their attributes will be unpacked into HTML markup from that:
 	{{ .form.AsDiv }}
 
+To protect the form against Cross-Site Request Forgery, create it with
+WithCSRF and wrap the handler with CSRFMiddleware. AsDiv renders the CSRF
+token in a hidden field and Form.IsValid checks it. An unbound form needs the
+request to render the token, it is given with Form.SetRequest.
+
 Bound and unbound forms
 
 A Form is either bound to a set of data, or unbound.
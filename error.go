@@ -21,7 +21,28 @@ const (
 	URLErrorCode       = "url"
 )
 
-var customizableErrors = []string{BooleanErrorCode, EmailErrorCode, ChoiceErrorCode, MinLengthErrorCode, MaxLengthErrorCode, RequiredErrorCode, URLErrorCode}
+// NonFieldErrorsKey is the FormErrors key of errors not attached to a field.
+// See Form.NonFieldErrors.
+const NonFieldErrorsKey = "__all__"
+
+// Error codes of the validations involving several fields. See RequiredIf,
+// FieldsEqual, AtLeastOneOf and MutuallyExclusive. RequiredIf uses
+// RequiredErrorCode.
+const (
+	FieldsEqualErrorCode       = "fields_equal"
+	AtLeastOneOfErrorCode      = "at_least_one_of"
+	MutuallyExclusiveErrorCode = "mutually_exclusive"
+)
+
+// Error codes of the built-in validators. See Regex, NotIn and Integer.
+// MinLength and MaxLength use MinLengthErrorCode and MaxLengthErrorCode.
+const (
+	RegexErrorCode   = "regex"
+	NotInErrorCode   = "not_in"
+	IntegerErrorCode = "integer"
+)
+
+var customizableErrors = []string{BooleanErrorCode, EmailErrorCode, ChoiceErrorCode, MinLengthErrorCode, MaxLengthErrorCode, RequiredErrorCode, URLErrorCode, FieldsEqualErrorCode, AtLeastOneOfErrorCode, MutuallyExclusiveErrorCode, CaptchaErrorCode, SignatureErrorCode, RegexErrorCode, NotInErrorCode, IntegerErrorCode, NumberErrorCode, DateErrorCode}
 
 // ErrorCoderTranslator defines the validation errors interface.
 type ErrorCoderTranslator interface {
@@ -86,6 +107,22 @@ func (e Error) Translate(locale string) string {
 	return e.Error()
 }
 
+// errorParamser is implemented by the built-in errors having positional
+// parameters.
+type errorParamser interface {
+	errorParams() []string
+}
+
+// params returns the positional parameters of the error, or nil if it has
+// none.
+func (e Error) params() []string {
+	var ep errorParamser
+	if errors.As(e.err, &ep) {
+		return ep.errorParams()
+	}
+	return nil
+}
+
 // ErrorWrap wraps an error in a validation Error. It makes possible to use any
 // error as a validation Error.
 func ErrorWrap(err error) Error {
@@ -138,32 +175,55 @@ func (e errorFromFieldError) Code() string {
 	return e.fieldError.Tag()
 }
 
+func (e errorFromFieldError) errorParams() []string {
+	return []string{e.fieldError.Param()}
+}
+
 func (e errorFromFieldError) Translate(locale string) string {
-	trans, _ := universalTranslator.GetTranslator(locale)
-	return e.fieldError.Translate(trans)
+	return translateMessage(locale, e.Code(), []string{e.fieldError.Param()}, func(tag language.Tag) (string, bool) {
+		if !isRegisteredLanguage(tag) {
+			return "", false
+		}
+		trans, found := universalTranslator.GetTranslator(translatorLocale(tag.String()))
+		if !found {
+			return "", false
+		}
+		return e.fieldError.Translate(trans), true
+	})
 }
 
+// simpleError is an error with built-in English and French message templates.
+// params fill the templates of all languages.
 type simpleError struct {
-	code string
-	fr   string
-	en   string
+	code   string
+	fr     string
+	en     string
+	params []string
 }
 
 func (e simpleError) Code() string {
 	return e.code
 }
 
+func (e simpleError) errorParams() []string {
+	return e.params
+}
+
 func (e simpleError) Error() string {
-	return RequiredErrorMessageEn
+	return formatMessage(localeTranslator(language.English), e.en, e.params)
 }
 
 func (e simpleError) Translate(locale string) string {
-	switch locale {
-	case language.French.String():
-		return e.fr
-	default:
-		return e.en
-	}
+	return translateMessage(locale, e.code, e.params, func(tag language.Tag) (string, bool) {
+		switch tag {
+		case language.French:
+			return e.fr, true
+		case language.English:
+			return e.en, true
+		default:
+			return "", false
+		}
+	})
 }
 
 var (
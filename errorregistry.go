@@ -0,0 +1,104 @@
+package aform
+
+import (
+	"fmt"
+	"golang.org/x/exp/slices"
+	"golang.org/x/text/language"
+	"strings"
+	"sync"
+)
+
+// ErrorMessages maps a language to the message template of an error code.
+// Templates can contain positional parameters {0}, {1}, ... replaced by the
+// parameters given to NewError and plural blocks, see Messages. e.g.
+//
+//	ErrorMessages{
+//		language.English: "Ensure this value is a multiple of {0}",
+//		language.French:  "Assurez-vous que cette valeur est un multiple de {0}",
+//	}
+type ErrorMessages map[language.Tag]string
+
+var errorRegistry = struct {
+	sync.RWMutex
+	messages map[string]ErrorMessages
+}{messages: map[string]ErrorMessages{}}
+
+// RegisterErrorCode registers an application-defined error code with its
+// message templates. Once registered, errors with this code can be created
+// with NewError, translated with Error.Translate and customized with
+// Field.CustomizeError like built-in errors. messages must contain an English
+// template. It returns an error if code is empty, if it is a built-in code,
+// if it starts with TextKeyPrefix or if it is already registered.
+func RegisterErrorCode(code string, messages ErrorMessages) error {
+	if len(code) == 0 {
+		return fmt.Errorf("error code can't be empty")
+	}
+	if slices.Contains(customizableErrors, code) {
+		return fmt.Errorf("error code %s is a built-in code", code)
+	}
+	if strings.HasPrefix(code, TextKeyPrefix) {
+		return fmt.Errorf("error code %s can't start with %s", code, TextKeyPrefix)
+	}
+	if _, ok := messages[defaultLanguage]; !ok {
+		return fmt.Errorf("error code %s must have an English message", code)
+	}
+	errorRegistry.Lock()
+	defer errorRegistry.Unlock()
+	if _, ok := errorRegistry.messages[code]; ok {
+		return fmt.Errorf("error code %s is already registered", code)
+	}
+	copied := ErrorMessages{}
+	for tag, message := range messages {
+		copied[tag] = message
+	}
+	errorRegistry.messages[code] = copied
+	return nil
+}
+
+// NewError returns an Error with the code registered with RegisterErrorCode.
+// params replace the positional parameters {0}, {1}, ... of the message
+// templates. It panics if code is not registered.
+func NewError(code string, params ...string) Error {
+	if !isRegisteredErrorCode(code) {
+		panic(fmt.Sprintf("NewError called with an unregistered error code: %s", code))
+	}
+	return ErrorWrap(registeredError{code: code, params: params})
+}
+
+func isRegisteredErrorCode(code string) bool {
+	errorRegistry.RLock()
+	defer errorRegistry.RUnlock()
+	_, ok := errorRegistry.messages[code]
+	return ok
+}
+
+// registeredError is an error whose messages are registered with
+// RegisterErrorCode.
+type registeredError struct {
+	code   string
+	params []string
+}
+
+func (e registeredError) Code() string {
+	return e.code
+}
+
+func (e registeredError) errorParams() []string {
+	return e.params
+}
+
+func (e registeredError) Error() string {
+	return e.Translate(defaultLanguage.String())
+}
+
+// Translate returns the message of the first locale of the fallback chain of
+// locale having one. e.g. "fr-CA", then "fr" and finally English.
+func (e registeredError) Translate(locale string) string {
+	errorRegistry.RLock()
+	messages := errorRegistry.messages[e.code]
+	errorRegistry.RUnlock()
+	return translateMessage(locale, e.code, e.params, func(tag language.Tag) (string, bool) {
+		message, ok := messages[tag]
+		return message, ok
+	})
+}
@@ -0,0 +1,94 @@
+package aform_test
+
+import (
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"testing"
+)
+
+const multipleOfErrorCode = "multiple_of"
+
+var multipleOfMessages = aform.ErrorMessages{
+	language.English: "Ensure this value is a multiple of {0}",
+	language.French:  "Assurez-vous que cette valeur est un multiple de {0}",
+}
+
+// registerErrorCode registers code with RegisterErrorCode until the end of
+// the test t.
+func registerErrorCode(t *testing.T, code string, messages aform.ErrorMessages) {
+	t.Helper()
+	if err := aform.RegisterErrorCode(code, messages); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		aform.ExportUnregisterErrorCode(code)
+	})
+}
+
+func TestRegisterErrorCode_invalid(t *testing.T) {
+	registerErrorCode(t, multipleOfErrorCode, multipleOfMessages)
+	tests := []struct {
+		name     string
+		code     string
+		messages aform.ErrorMessages
+	}{
+		{name: "empty code", code: "", messages: aform.ErrorMessages{language.English: "Invalid"}},
+		{name: "built-in code", code: aform.RequiredErrorCode, messages: aform.ErrorMessages{language.English: "Invalid"}},
+		{name: "already registered", code: multipleOfErrorCode, messages: aform.ErrorMessages{language.English: "Invalid"}},
+		{name: "no English message", code: "no_english", messages: aform.ErrorMessages{language.French: "Invalide"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, aform.RegisterErrorCode(tt.code, tt.messages))
+		})
+	}
+}
+
+func TestNewError(t *testing.T) {
+	a := assert.New(t)
+	registerErrorCode(t, multipleOfErrorCode, multipleOfMessages)
+	err := aform.NewError(multipleOfErrorCode, "5")
+	a.Equal(multipleOfErrorCode, err.Code())
+	a.Equal("Ensure this value is a multiple of 5", err.Error())
+	a.Equal("Ensure this value is a multiple of 5", err.Translate("en"))
+	a.Equal("Assurez-vous que cette valeur est un multiple de 5", err.Translate("fr"))
+	a.Equal("Assurez-vous que cette valeur est un multiple de 5", err.Translate("fr-CA"))
+	a.Equal("Ensure this value is a multiple of 5", err.Translate("de"))
+	a.Panics(func() { aform.NewError("unregistered") })
+}
+
+func TestNewError_inForm(t *testing.T) {
+	a := assert.New(t)
+	registerErrorCode(t, multipleOfErrorCode, multipleOfMessages)
+	multipleOfFive := func(value string) error {
+		if value[len(value)-1] != '0' && value[len(value)-1] != '5' {
+			return aform.NewError(multipleOfErrorCode, "5")
+		}
+		return nil
+	}
+	fld := aform.Must(aform.DefaultCharField("Quantity", aform.WithValidators(aform.Integer(), multipleOfFive)))
+	f := aform.Must(aform.New(aform.WithLocales([]language.Tag{language.English, language.French}), aform.WithCharField(fld)))
+	f.BindData(map[string][]string{"quantity": {"12"}}, "fr")
+	a.False(f.IsValid())
+	a.Equal(multipleOfErrorCode, f.Errors().Get("quantity").Code())
+	a.Equal(`<ul class="errorlist"><li id="err_0_id_quantity">Assurez-vous que cette valeur est un multiple de 5</li></ul>`, string(fld.Errors()))
+}
+
+func TestField_CustomizeError_registeredCode(t *testing.T) {
+	a := assert.New(t)
+	registerErrorCode(t, multipleOfErrorCode, multipleOfMessages)
+	fld := aform.Must(aform.DefaultCharField("Quantity", aform.WithValidators(func(string) error {
+		return aform.NewError(multipleOfErrorCode, "5")
+	})))
+	a.NotPanics(func() {
+		fld.CustomizeError(aform.ErrorWrapWithCode(errWrongAnswer, multipleOfErrorCode))
+	})
+	_, errs := fld.Clean("12")
+	if a.Len(errs, 1) {
+		a.Equal(errWrongAnswer.Error(), errs[0].Error())
+	}
+	a.Panics(func() {
+		fld.CustomizeError(aform.ErrorWrapWithCode(errWrongAnswer, "unregistered"))
+	})
+}
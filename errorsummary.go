@@ -0,0 +1,102 @@
+package aform
+
+import (
+	"fmt"
+	"html/template"
+)
+
+// ErrorSummaryTitleCode is the message code of the title of the error
+// summary. See Form.ErrorSummary.
+const ErrorSummaryTitleCode = "error_summary_title"
+
+// English and French titles of the error summary.
+const (
+	ErrorSummaryTitleEn = "There is a problem"
+	ErrorSummaryTitleFr = "Il y a un problème"
+)
+
+// ErrorSummaryFocus defines which element gets the focus when a form with
+// errors is rendered. See WithErrorSummaryFocus.
+type ErrorSummaryFocus string
+
+// Elements that can get the focus when a form with errors is rendered.
+const (
+	// FocusErrorSummary sets the autofocus attribute on the error summary.
+	FocusErrorSummary = ErrorSummaryFocus("summary")
+	// FocusFirstInvalidField sets the autofocus attribute on the widget of
+	// the first field having errors, or on its first input for RadioSelect
+	// and CheckboxSelectMultiple widgets.
+	FocusFirstInvalidField = ErrorSummaryFocus("first_invalid_field")
+)
+
+// WithErrorSummaryFocus returns a FormOption that moves the focus to the
+// error summary or to the first invalid field when the form has errors. By
+// default, nothing gets the focus.
+func WithErrorSummaryFocus(focus ErrorSummaryFocus) FormOption {
+	return func(f *Form) error {
+		if focus != FocusErrorSummary && focus != FocusFirstInvalidField {
+			return fmt.Errorf("unknown error summary focus %q", focus)
+		}
+		f.summaryFocus = focus
+		return nil
+	}
+}
+
+// ErrorSummary renders the errors of the form in a summary placed at the top
+// of the page. The summary is a <div> tag with role="alert" containing a
+// title and a <ul> tag. Non-field errors are listed first, then the errors of
+// the fields in the order of the fields. Field errors are translated with
+// Field.TranslateSummaryError and link to the widget of the field, e.g.
+// <a href="#id_first_name">, or to its first input for RadioSelect and
+// CheckboxSelectMultiple widgets, e.g. <a href="#id_color_0">. ErrorSummary
+// returns the empty string if the form isn't bound or has no error. It does
+// Form validation if it is not already done.
+func (f *Form) ErrorSummary() template.HTML {
+	if len(f.Errors()) == 0 {
+		return ""
+	}
+	var list []tmplSummaryItem
+	for _, err := range f.errors[NonFieldErrorsKey] {
+		list = append(list, tmplSummaryItem{Text: err.Translate(f.locale.String())})
+	}
+	for _, fld := range f.fields {
+		href := ""
+		if hasID(fld) {
+			href = "#" + normalizedFocusableIDForField(fld.field())
+		}
+		for _, err := range f.errors[normalizedNameForField(fld)] {
+			list = append(list, tmplSummaryItem{Text: fld.TranslateSummaryError(err), Href: href})
+		}
+	}
+	id := prefixedName(f.prefix, "error_summary")
+	attrs := tmplAttrs{
+		"class":           "error-summary",
+		"id":              id,
+		"role":            "alert",
+		"aria-labelledby": id + "_title",
+		"tabindex":        "-1",
+	}
+	if f.summaryFocus == FocusErrorSummary {
+		attrs["autofocus"] = ""
+	}
+	title := simpleError{code: ErrorSummaryTitleCode, fr: ErrorSummaryTitleFr, en: ErrorSummaryTitleEn}
+	return mustErrorSummaryTemplate(&tmplErrorSummary{
+		Title: tmplError{Text: title.Translate(f.locale.String()), Attrs: tmplAttrs{"id": id + "_title"}},
+		List:  list,
+		Attrs: attrs,
+	})
+}
+
+// setAutofocus sets the autofocus attribute on the first field having errors
+// when the form is created with FocusFirstInvalidField.
+func (f *Form) setAutofocus() {
+	if f.summaryFocus != FocusFirstInvalidField {
+		return
+	}
+	focused := false
+	for _, fld := range f.fields {
+		invalid := len(f.errors[normalizedNameForField(fld)]) > 0
+		fld.field().autofocus = invalid && !focused
+		focused = focused || invalid
+	}
+}
@@ -0,0 +1,259 @@
+package aform_test
+
+import (
+	"fmt"
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"html/template"
+	"strings"
+	"testing"
+)
+
+func TestForm_ErrorSummary(t *testing.T) {
+	tests := []struct {
+		name   string
+		form   *aform.Form
+		data   map[string][]string
+		locale string
+		want   template.HTML
+	}{
+		{
+			name: "field order",
+			form: aform.Must(aform.New(
+				aform.WithCharField(aform.Must(aform.DefaultCharField("First name"))),
+				aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))),
+				aform.WithCharField(aform.Must(aform.NewCharField("Password", "", "", 8, 0))),
+				aform.WithLocales([]language.Tag{language.English, language.French}),
+			)),
+			data:   map[string][]string{"first_name": {""}, "email": {"jane"}, "password": {"short"}},
+			locale: "en",
+			want: `<div class="error-summary" id="error_summary" role="alert" aria-labelledby="error_summary_title" tabindex="-1">
+<h2 id="error_summary_title">There is a problem</h2>
+<ul class="error-summary-list">
+<li><a href="#id_first_name">First name is required</a></li>
+<li><a href="#id_email">Enter a valid email address in Email</a></li>
+<li><a href="#id_password">Password must have at least 8 characters</a></li>
+</ul>
+</div>`,
+		},
+		{
+			name: "french",
+			form: aform.Must(aform.New(
+				aform.WithCharField(aform.Must(aform.DefaultCharField("First name"))),
+				aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))),
+				aform.WithCharField(aform.Must(aform.NewCharField("Password", "", "", 8, 0))),
+				aform.WithLocales([]language.Tag{language.English, language.French}),
+			)),
+			data:   map[string][]string{"first_name": {"Jane"}, "email": {""}, "password": {"password"}},
+			locale: "fr",
+			want: `<div class="error-summary" id="error_summary" role="alert" aria-labelledby="error_summary_title" tabindex="-1">
+<h2 id="error_summary_title">Il y a un problème</h2>
+<ul class="error-summary-list">
+<li><a href="#id_email">Email est obligatoire</a></li>
+</ul>
+</div>`,
+		},
+		{
+			name: "prefix and custom auto id",
+			form: aform.Must(aform.New(
+				aform.WithCharField(aform.Must(aform.DefaultCharField("First name"))),
+				aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))),
+				aform.WithCharField(aform.Must(aform.NewCharField("Password", "", "", 8, 0))),
+				aform.WithLocales([]language.Tag{language.English, language.French}),
+				aform.WithPrefix("signup"),
+				aform.WithAutoID("field_%s"),
+			)),
+			data:   map[string][]string{"signup-first_name": {""}, "signup-email": {"jane@example.com"}, "signup-password": {"password"}},
+			locale: "en",
+			want: `<div class="error-summary" id="signup-error_summary" role="alert" aria-labelledby="signup-error_summary_title" tabindex="-1">
+<h2 id="signup-error_summary_title">There is a problem</h2>
+<ul class="error-summary-list">
+<li><a href="#field_signup-first_name">First name is required</a></li>
+</ul>
+</div>`,
+		},
+		{
+			name: "disabled auto id",
+			form: aform.Must(aform.New(
+				aform.WithCharField(aform.Must(aform.DefaultCharField("First name"))),
+				aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))),
+				aform.WithCharField(aform.Must(aform.NewCharField("Password", "", "", 8, 0))),
+				aform.WithLocales([]language.Tag{language.English, language.French}),
+				aform.DisableAutoID(),
+			)),
+			data:   map[string][]string{"first_name": {""}, "email": {"jane@example.com"}, "password": {"password"}},
+			locale: "en",
+			want: `<div class="error-summary" id="error_summary" role="alert" aria-labelledby="error_summary_title" tabindex="-1">
+<h2 id="error_summary_title">There is a problem</h2>
+<ul class="error-summary-list">
+<li>First name is required</li>
+</ul>
+</div>`,
+		},
+		{
+			name: "autofocus",
+			form: aform.Must(aform.New(
+				aform.WithCharField(aform.Must(aform.DefaultCharField("First name"))),
+				aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))),
+				aform.WithCharField(aform.Must(aform.NewCharField("Password", "", "", 8, 0))),
+				aform.WithLocales([]language.Tag{language.English, language.French}),
+				aform.WithErrorSummaryFocus(aform.FocusErrorSummary),
+			)),
+			data:   map[string][]string{"first_name": {"Jane"}, "email": {"jane"}, "password": {"password"}},
+			locale: "en",
+			want: `<div class="error-summary" id="error_summary" role="alert" aria-labelledby="error_summary_title" autofocus tabindex="-1">
+<h2 id="error_summary_title">There is a problem</h2>
+<ul class="error-summary-list">
+<li><a href="#id_email">Enter a valid email address in Email</a></li>
+</ul>
+</div>`,
+		},
+		{
+			name: "valid",
+			form: aform.Must(aform.New(
+				aform.WithCharField(aform.Must(aform.DefaultCharField("First name"))),
+				aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))),
+				aform.WithCharField(aform.Must(aform.NewCharField("Password", "", "", 8, 0))),
+				aform.WithLocales([]language.Tag{language.English, language.French}),
+			)),
+			data:   map[string][]string{"first_name": {"Jane"}, "email": {"jane@example.com"}, "password": {"password"}},
+			locale: "en",
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			tt.form.BindData(tt.data, tt.locale)
+			a.Equal(tt.want, tt.form.ErrorSummary())
+		})
+	}
+}
+
+func TestForm_ErrorSummary_deterministic(t *testing.T) {
+	a := assert.New(t)
+	newForm := func() *aform.Form {
+		return aform.Must(aform.New(
+			aform.WithCharField(aform.Must(aform.DefaultCharField("First name"))),
+			aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))),
+			aform.WithCharField(aform.Must(aform.NewCharField("Password", "", "", 8, 0))),
+			aform.WithLocales([]language.Tag{language.English, language.French}),
+		))
+	}
+	data := map[string][]string{"first_name": {""}, "email": {""}, "password": {""}}
+	f := newForm()
+	f.BindData(data)
+	want := f.ErrorSummary()
+	for i := 0; i < 20; i++ {
+		f := newForm()
+		f.BindData(data)
+		a.Equal(want, f.ErrorSummary())
+	}
+}
+
+func TestForm_ErrorSummary_nonFieldErrors(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithCharField(aform.Must(aform.DefaultCharField("First name"))),
+		aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))),
+		aform.WithCharField(aform.Must(aform.NewCharField("Password", "", "", 8, 0))),
+		aform.WithLocales([]language.Tag{language.English, language.French}),
+	))
+	f.BindData(map[string][]string{"first_name": {""}, "email": {"jane@example.com"}, "password": {"password"}})
+	a.False(f.IsValid())
+	a.NoError(f.AddError("", fmt.Errorf("Registrations are closed")))
+	a.NoError(f.AddError("password", fmt.Errorf("Password is too common")))
+	a.Equal(template.HTML(`<div class="error-summary" id="error_summary" role="alert" aria-labelledby="error_summary_title" tabindex="-1">
+<h2 id="error_summary_title">There is a problem</h2>
+<ul class="error-summary-list">
+<li>Registrations are closed</li>
+<li><a href="#id_first_name">First name is required</a></li>
+<li><a href="#id_password">Password: Password is too common</a></li>
+</ul>
+</div>`), f.ErrorSummary())
+}
+
+func TestForm_ErrorSummary_unbound(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithCharField(aform.Must(aform.DefaultCharField("First name"))),
+		aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))),
+		aform.WithCharField(aform.Must(aform.NewCharField("Password", "", "", 8, 0))),
+		aform.WithLocales([]language.Tag{language.English, language.French}),
+	))
+	a.Equal(template.HTML(""), f.ErrorSummary())
+}
+
+func TestWithErrorSummaryFocus_firstInvalidField(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithCharField(aform.Must(aform.DefaultCharField("First name"))),
+		aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))),
+		aform.WithCharField(aform.Must(aform.NewCharField("Password", "", "", 8, 0))),
+		aform.WithLocales([]language.Tag{language.English, language.French}),
+		aform.WithErrorSummaryFocus(aform.FocusFirstInvalidField),
+	))
+	f.BindData(map[string][]string{"first_name": {"Jane"}, "email": {"jane"}, "password": {"short"}})
+	a.False(f.IsValid())
+	email, _ := f.FieldByName("Email")
+	password, _ := f.FieldByName("Password")
+	a.Contains(string(email.Widget()), " autofocus")
+	a.NotContains(string(password.Widget()), "autofocus")
+	a.NotContains(string(f.ErrorSummary()), "autofocus")
+}
+
+func TestWithErrorSummaryFocus_addError(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithCharField(aform.Must(aform.DefaultCharField("First name"))),
+		aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))),
+		aform.WithCharField(aform.Must(aform.NewCharField("Password", "", "", 8, 0))),
+		aform.WithLocales([]language.Tag{language.English, language.French}),
+		aform.WithErrorSummaryFocus(aform.FocusFirstInvalidField),
+	))
+	f.BindData(map[string][]string{"first_name": {"Jane"}, "email": {"jane@example.com"}, "password": {"short"}})
+	a.False(f.IsValid())
+	a.NoError(f.AddError("first_name", fmt.Errorf("Unknown first name")))
+	firstName, _ := f.FieldByName("First name")
+	password, _ := f.FieldByName("Password")
+	a.Contains(string(firstName.Widget()), " autofocus")
+	a.NotContains(string(password.Widget()), "autofocus")
+}
+
+func TestWithErrorSummaryFocus_invalid(t *testing.T) {
+	_, err := aform.New(aform.WithErrorSummaryFocus("label"))
+	assert.Error(t, err)
+}
+
+func TestForm_ErrorSummary_multipleInputWidgets(t *testing.T) {
+	tests := []struct {
+		name   string
+		field  aform.FormOption
+		wantID string
+	}{
+		{
+			name:   "radio",
+			field:  aform.WithChoiceField(aform.Must(aform.DefaultChoiceField("Color", aform.WithWidget(aform.RadioSelect), aform.WithChoiceOptions([]aform.ChoiceFieldOption{{Value: "red"}, {Value: "blue"}})))),
+			wantID: "id_color_0",
+		},
+		{
+			name:   "checkbox",
+			field:  aform.WithMultipleChoiceField(aform.Must(aform.DefaultMultipleChoiceField("Color", aform.WithWidget(aform.CheckboxSelectMultiple), aform.WithChoiceOptions([]aform.ChoiceFieldOption{{Value: "red"}, {Value: "blue"}})))),
+			wantID: "id_color_0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			f := aform.Must(aform.New(tt.field, aform.WithErrorSummaryFocus(aform.FocusFirstInvalidField)))
+			f.BindData(map[string][]string{})
+			a.False(f.IsValid())
+			a.Contains(string(f.ErrorSummary()), `href="#`+tt.wantID+`"`)
+			fld, _ := f.FieldByName("Color")
+			widget := string(fld.Widget())
+			a.Equal(1, strings.Count(widget, "autofocus"))
+			a.Contains(widget, `id="`+tt.wantID+`" autofocus`)
+		})
+	}
+}
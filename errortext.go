@@ -0,0 +1,165 @@
+package aform
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SummaryCodeSuffix is appended to an error code to get the code of its
+// summary message. Summary messages name the field because they are rendered
+// away from it, e.g. in an error summary at the top of the form. They are
+// registered like other messages with RegisterMessages. e.g.
+//
+//	RegisterMessages(language.English, Messages{RequiredErrorCode + SummaryCodeSuffix: "Enter your {label}"})
+const SummaryCodeSuffix = ".summary"
+
+// English summary messages of the built-in errors of the fields. See
+// Field.TranslateSummaryError.
+const (
+	BooleanErrorSummaryEn     = "{label} must be a valid boolean"
+	EmailErrorSummaryEn       = "Enter a valid email address in {label}"
+	ChoiceErrorSummaryEn      = "Select a valid choice for {label}"
+	MinLengthErrorSummaryEn   = "{label} must have at least {min, plural, one {# character} other {# characters}}"
+	MaxLengthErrorSummaryEn   = "{label} must have at most {max, plural, one {# character} other {# characters}}"
+	RequiredErrorSummaryEn    = "{label} is required"
+	URLErrorSummaryEn         = "Enter a valid URL in {label}"
+	FieldsEqualErrorSummaryEn = "{label} must match {0}"
+	RegexErrorSummaryEn       = "Enter a valid value in {label}"
+	NotInErrorSummaryEn       = "This value is not allowed in {label}"
+	IntegerErrorSummaryEn     = "{label} must be a whole number"
+	NumberErrorSummaryEn      = "{label} must be a number"
+	DateErrorSummaryEn        = "{label} must be a valid date"
+	CaptchaErrorSummaryEn     = "Wrong answer to {label}, please try again"
+	SignatureErrorSummaryEn   = "{label} has been modified"
+)
+
+// French summary messages of the built-in errors of the fields. See
+// Field.TranslateSummaryError.
+const (
+	BooleanErrorSummaryFr     = "{label} doit être un booléen valide"
+	EmailErrorSummaryFr       = "Entrez une adresse e-mail valide dans {label}"
+	ChoiceErrorSummaryFr      = "Sélectionnez un choix valide pour {label}"
+	MinLengthErrorSummaryFr   = "{label} doit faire au minimum {min, plural, one {# caractère} other {# caractères}}"
+	MaxLengthErrorSummaryFr   = "{label} doit faire au maximum {max, plural, one {# caractère} other {# caractères}}"
+	RequiredErrorSummaryFr    = "{label} est obligatoire"
+	URLErrorSummaryFr         = "Entrez une URL valide dans {label}"
+	FieldsEqualErrorSummaryFr = "{label} doit correspondre à {0}"
+	RegexErrorSummaryFr       = "Entrez une valeur valide dans {label}"
+	NotInErrorSummaryFr       = "Cette valeur n'est pas autorisée dans {label}"
+	IntegerErrorSummaryFr     = "{label} doit être un nombre entier"
+	NumberErrorSummaryFr      = "{label} doit être un nombre"
+	DateErrorSummaryFr        = "{label} doit être une date valide"
+	CaptchaErrorSummaryFr     = "Mauvaise réponse à {label}, veuillez réessayer"
+	SignatureErrorSummaryFr   = "{label} a été modifié"
+)
+
+var (
+	errorSummariesEn = Messages{
+		BooleanErrorCode + SummaryCodeSuffix:     BooleanErrorSummaryEn,
+		EmailErrorCode + SummaryCodeSuffix:       EmailErrorSummaryEn,
+		ChoiceErrorCode + SummaryCodeSuffix:      ChoiceErrorSummaryEn,
+		MinLengthErrorCode + SummaryCodeSuffix:   MinLengthErrorSummaryEn,
+		MaxLengthErrorCode + SummaryCodeSuffix:   MaxLengthErrorSummaryEn,
+		RequiredErrorCode + SummaryCodeSuffix:    RequiredErrorSummaryEn,
+		URLErrorCode + SummaryCodeSuffix:         URLErrorSummaryEn,
+		FieldsEqualErrorCode + SummaryCodeSuffix: FieldsEqualErrorSummaryEn,
+		RegexErrorCode + SummaryCodeSuffix:       RegexErrorSummaryEn,
+		NotInErrorCode + SummaryCodeSuffix:       NotInErrorSummaryEn,
+		IntegerErrorCode + SummaryCodeSuffix:     IntegerErrorSummaryEn,
+		NumberErrorCode + SummaryCodeSuffix:      NumberErrorSummaryEn,
+		DateErrorCode + SummaryCodeSuffix:        DateErrorSummaryEn,
+		CaptchaErrorCode + SummaryCodeSuffix:     CaptchaErrorSummaryEn,
+		SignatureErrorCode + SummaryCodeSuffix:   SignatureErrorSummaryEn,
+	}
+	errorSummariesFr = Messages{
+		BooleanErrorCode + SummaryCodeSuffix:     BooleanErrorSummaryFr,
+		EmailErrorCode + SummaryCodeSuffix:       EmailErrorSummaryFr,
+		ChoiceErrorCode + SummaryCodeSuffix:      ChoiceErrorSummaryFr,
+		MinLengthErrorCode + SummaryCodeSuffix:   MinLengthErrorSummaryFr,
+		MaxLengthErrorCode + SummaryCodeSuffix:   MaxLengthErrorSummaryFr,
+		RequiredErrorCode + SummaryCodeSuffix:    RequiredErrorSummaryFr,
+		URLErrorCode + SummaryCodeSuffix:         URLErrorSummaryFr,
+		FieldsEqualErrorCode + SummaryCodeSuffix: FieldsEqualErrorSummaryFr,
+		RegexErrorCode + SummaryCodeSuffix:       RegexErrorSummaryFr,
+		NotInErrorCode + SummaryCodeSuffix:       NotInErrorSummaryFr,
+		IntegerErrorCode + SummaryCodeSuffix:     IntegerErrorSummaryFr,
+		NumberErrorCode + SummaryCodeSuffix:      NumberErrorSummaryFr,
+		DateErrorCode + SummaryCodeSuffix:        DateErrorSummaryFr,
+		CaptchaErrorCode + SummaryCodeSuffix:     CaptchaErrorSummaryFr,
+		SignatureErrorCode + SummaryCodeSuffix:   SignatureErrorSummaryFr,
+	}
+)
+
+// TranslateError returns the message of err translated with the locale of
+// the Field. Besides the positional parameters of err, the message can
+// contain the named placeholders of the Field:
+//
+//   - {label} is the label of the Field
+//   - {value} is the bound value of the Field, empty for a PasswordInput or a
+//     signed or encrypted Field
+//   - {min} and {max} are the minimum and maximum lengths of the Field, or
+//     the length of a MinLength or MaxLength error
+//
+// Named placeholders can be used in plural blocks, see Messages. e.g.
+//
+//	RegisterMessages(language.English, Messages{RequiredErrorCode: "{label} is required"})
+//
+// Errors rendered by Errors are translated with TranslateError.
+func (fld *Field) TranslateError(err Error) string {
+	return fld.formatErrorText(err.Translate(fld.locale.String()), err)
+}
+
+// TranslateSummaryError returns the summary message of err translated with
+// the locale of the Field. Summary messages are registered with the code of
+// err followed by SummaryCodeSuffix. They are looked up for the locale of the
+// Field and its parents, e.g. "fr-CA" and "fr", and are filled like the
+// messages of TranslateError. Built-in errors have English and French summary
+// messages. If there is none, or if the error has been customized with
+// CustomizeError, the summary message is the label followed by the message
+// of TranslateError. e.g. "Vorname: Dieses Feld ist erforderlich".
+func (fld *Field) TranslateSummaryError(err Error) string {
+	if code := err.Code(); len(code) > 0 && !fld.isCustomizedError(code) {
+		for _, tag := range localeParents(fld.locale) {
+			if message, ok := lookupMessage(tag, code+SummaryCodeSuffix); ok {
+				return fld.formatErrorText(formatMessage(localeTranslator(fld.locale), message, err.params()), err)
+			}
+		}
+	}
+	return fld.localizedLabel() + ": " + fld.TranslateError(err)
+}
+
+func (fld *Field) isCustomizedError(code string) bool {
+	_, ok := fld.customErrors[code]
+	return ok
+}
+
+// formatErrorText replaces the named placeholders of the Field in message.
+func (fld *Field) formatErrorText(message string, err Error) string {
+	placeholders := map[string]string{
+		"label": fld.localizedLabel(),
+		"value": fld.errorValue(),
+		"min":   strconv.FormatUint(uint64(fld.minLength), 10),
+		"max":   strconv.FormatUint(uint64(fld.maxLength), 10),
+	}
+	if params := err.params(); len(params) > 0 {
+		switch err.Code() {
+		case MinLengthErrorCode:
+			placeholders["min"] = params[0]
+		case MaxLengthErrorCode:
+			placeholders["max"] = params[0]
+		}
+	}
+	return formatPlaceholders(localeTranslator(fld.locale), message, func(name string) (string, bool) {
+		value, ok := placeholders[name]
+		return value, ok
+	})
+}
+
+// errorValue returns the bound values rendered in error messages. The values
+// of a PasswordInput and of a signed or encrypted Field are never rendered.
+func (fld *Field) errorValue() string {
+	if fld.widget == PasswordInput || fld.isProtected() {
+		return ""
+	}
+	return strings.Join(fld.localizeValues(fld.boundValues), ", ")
+}
@@ -0,0 +1,168 @@
+package aform_test
+
+import (
+	"fmt"
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"html/template"
+	"testing"
+)
+
+const takenErrorCode = "taken"
+
+// registerTakenErrorCode registers takenErrorCode and its English summary
+// message until the end of the test t.
+func registerTakenErrorCode(t *testing.T) {
+	t.Helper()
+	registerErrorCode(t, takenErrorCode, aform.ErrorMessages{
+		language.English: "{value} is already taken, choose another {label}",
+		language.French:  "{value} est déjà pris, choisissez un autre {label}",
+	})
+	if err := aform.RegisterMessages(language.English, aform.Messages{
+		takenErrorCode + aform.SummaryCodeSuffix: "{label} {value} is already taken",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		aform.ExportDeleteMessages(language.English, takenErrorCode+aform.SummaryCodeSuffix)
+	})
+}
+
+func takenUsername(value string) error {
+	if value == "jane" {
+		return aform.NewError(takenErrorCode)
+	}
+	return nil
+}
+
+func TestField_TranslateSummaryError(t *testing.T) {
+	registerTakenErrorCode(t)
+	tests := []struct {
+		name   string
+		field  aform.FormField
+		value  string
+		locale string
+		want   string
+	}{
+		{name: "required en", field: aform.Must(aform.DefaultCharField("First name")), value: "", locale: "en", want: "First name is required"},
+		{name: "required fr", field: aform.Must(aform.DefaultCharField("First name", aform.WithLabelT(map[language.Tag]string{language.French: "Prénom"}))), value: "", locale: "fr", want: "Prénom est obligatoire"},
+		{name: "region fr-CA", field: aform.Must(aform.DefaultCharField("Prénom")), value: "", locale: "fr-CA", want: "Prénom est obligatoire"},
+		{name: "min length field", field: aform.Must(aform.NewCharField("Password", "", "", 3, 0)), value: "ab", locale: "en", want: "Password must have at least 3 characters"},
+		{name: "max length one", field: aform.Must(aform.NewCharField("Initial", "", "", 0, 1)), value: "ab", locale: "en", want: "Initial must have at most 1 character"},
+		{name: "max length one fr", field: aform.Must(aform.NewCharField("Initiale", "", "", 0, 1)), value: "ab", locale: "fr", want: "Initiale doit faire au maximum 1 caractère"},
+		{name: "max length validator", field: aform.Must(aform.DefaultCharField("Code", aform.WithValidators(aform.MaxLength(2)))), value: "abc", locale: "en", want: "Code must have at most 2 characters"},
+		{name: "email", field: aform.Must(aform.DefaultEmailField("Email")), value: "jane", locale: "en", want: "Enter a valid email address in Email"},
+		{name: "no summary in German", field: aform.Must(aform.DefaultCharField("Vorname")), value: "", locale: "de", want: "Vorname: Dieses Feld ist erforderlich"},
+		{name: "registered summary", field: aform.Must(aform.DefaultCharField("Username", aform.WithValidators(takenUsername))), value: "jane", locale: "en", want: "Username jane is already taken"},
+		{name: "registered without summary", field: aform.Must(aform.DefaultCharField("Pseudo", aform.WithValidators(takenUsername))), value: "jane", locale: "fr", want: "Pseudo: jane est déjà pris, choisissez un autre Pseudo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			f := aform.Must(aform.New(aform.WithLocales([]language.Tag{language.English, language.French, language.German})))
+			a.NoError(f.AddField(tt.field))
+			fld := f.Fields()[0]
+			f.BindData(map[string][]string{fld.HTMLName(): {tt.value}}, tt.locale)
+			a.False(f.IsValid())
+			a.Equal(tt.want, fld.TranslateSummaryError(f.Errors()[fld.HTMLName()][0]))
+		})
+	}
+}
+
+func TestField_TranslateSummaryError_fieldsEqual(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Password"))),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Confirmation"))),
+		aform.FieldsEqual("Password", "Confirmation"),
+	))
+	f.BindData(map[string][]string{"password": {"abcd"}, "confirmation": {"abce"}})
+	a.False(f.IsValid())
+	fld, _ := f.FieldByName("Confirmation")
+	a.Equal("Confirmation must match Password", fld.TranslateSummaryError(f.Errors()["confirmation"][0]))
+}
+
+func TestField_TranslateSummaryError_customized(t *testing.T) {
+	a := assert.New(t)
+	fld := aform.Must(aform.NewCharField("Name", "", "", 6, 0))
+	fld.CustomizeError(aform.ErrorWrapWithCode(fmt.Errorf("Please, enter enough characters"), aform.MinLengthErrorCode))
+	_, errs := fld.Clean("short")
+	a.Equal("Name: Please, enter enough characters", fld.TranslateSummaryError(errs[0]))
+}
+
+func TestField_TranslateError(t *testing.T) {
+	registerTakenErrorCode(t)
+	tests := []struct {
+		name  string
+		field *aform.CharField
+		value string
+		want  string
+	}{
+		{name: "label and value", field: aform.Must(aform.DefaultCharField("Username", aform.WithValidators(takenUsername))), value: "jane", want: "jane is already taken, choose another Username"},
+		{name: "password value", field: aform.Must(aform.DefaultCharField("Password", aform.WithWidget(aform.PasswordInput), aform.WithValidators(takenUsername))), value: "jane", want: " is already taken, choose another Password"},
+		{name: "positional parameters", field: aform.Must(aform.NewCharField("Code", "", "", 3, 0)), value: "ab", want: "Ensure this value has at least 3 characters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			_, errs := tt.field.Clean(tt.value)
+			if a.Len(errs, 1) {
+				a.Equal(tt.want, tt.field.TranslateError(errs[0]))
+			}
+		})
+	}
+}
+
+func TestField_TranslateError_namedPlural(t *testing.T) {
+	a := assert.New(t)
+	registerErrorCode(t, "too_long", aform.ErrorMessages{
+		language.English: "{label} accepts {max, plural, one {# character} other {# characters}}, not {value}",
+	})
+	fld := aform.Must(aform.NewCharField("Initial", "", "", 0, 1))
+	fld.AddValidators(func(value string) error {
+		return aform.NewError("too_long")
+	})
+	_, errs := fld.Clean("J")
+	if a.Len(errs, 1) {
+		a.Equal("Initial accepts 1 character, not J", fld.TranslateError(errs[0]))
+		a.Equal("{label} accepts {max, plural, one {# character} other {# characters}}, not {value}", errs[0].Translate("en"))
+	}
+}
+
+func TestField_TranslateError_protectedValue(t *testing.T) {
+	registerTakenErrorCode(t)
+	tests := []struct {
+		name string
+		opt  aform.FieldOption
+	}{
+		{name: "signed", opt: aform.WithSigned(signingSecret)},
+		{name: "encrypted", opt: aform.WithEncrypted(signingSecret)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			newForm := func() *aform.Form {
+				return aform.Must(aform.New(
+					aform.WithCharField(aform.Must(aform.NewCharField("Job ID", "jane", "", 0, 0, aform.WithWidget(aform.HiddenInput), tt.opt, aform.WithValidators(takenUsername)))),
+				))
+			}
+			f := newForm()
+			rendered := renderedJobID(t, f)
+			f = newForm()
+			f.BindData(map[string][]string{"job_id": {rendered}})
+			a.False(f.IsValid())
+			fld, _ := f.FieldByName("Job ID")
+			a.Equal(" is already taken, choose another Job ID", fld.TranslateError(f.Errors().Get("job_id")))
+			a.Equal("Job ID  is already taken", fld.TranslateSummaryError(f.Errors().Get("job_id")))
+		})
+	}
+}
+
+func TestField_Errors_placeholders(t *testing.T) {
+	a := assert.New(t)
+	registerTakenErrorCode(t)
+	fld := aform.Must(aform.DefaultCharField("Username", aform.WithValidators(takenUsername)))
+	fld.Clean("jane")
+	a.Equal(template.HTML(`<ul class="errorlist"><li id="err_0_id_username">jane is already taken, choose another Username</li></ul>`), fld.Errors())
+}
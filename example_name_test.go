@@ -20,7 +20,10 @@ func nameHandler(w http.ResponseWriter, req *http.Request) {
 	// if this is a POST request we need to process the form data
 	if req.Method == "POST" {
 		// Populate the form with data from the request:
-		nameForm.BindRequest(req)
+		if err := nameForm.BindRequest(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		// check whether it's valid:
 		if nameForm.IsValid() {
 			// process the data with Form.CleanedData
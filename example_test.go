@@ -3,6 +3,7 @@ package aform_test
 import (
 	"fmt"
 	"github.com/roleupjobboard/aform"
+	"golang.org/x/text/language"
 )
 
 func ExampleNew_yourName() {
@@ -160,6 +161,23 @@ func ExampleForm_SetCleanFunc() {
 	// <input type="email" name="email2" value="h@gmail.com" id="id_email2" maxlength="254" aria-describedby="err_0_id_email2" aria-invalid="true" required></div>
 }
 
+func ExampleForm_ErrorSummary() {
+	f := aform.Must(aform.New(
+		aform.WithCharField(aform.Must(aform.DefaultCharField("First name"))),
+		aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))),
+	))
+	f.BindData(map[string][]string{"first_name": {""}, "email": {"invalid email"}})
+	fmt.Println(f.ErrorSummary())
+	// Output:
+	// <div class="error-summary" id="error_summary" role="alert" aria-labelledby="error_summary_title" tabindex="-1">
+	// <h2 id="error_summary_title">There is a problem</h2>
+	// <ul class="error-summary-list">
+	// <li><a href="#id_first_name">First name is required</a></li>
+	// <li><a href="#id_email">Enter a valid email address in Email</a></li>
+	// </ul>
+	// </div>
+}
+
 func ExampleWithLabelSuffix() {
 	f := aform.Must(aform.New(
 		aform.WithLabelSuffix(":"),
@@ -382,6 +400,15 @@ func ExampleField_SetLabel() {
 	// <label for="id_remember">Remember me</label>
 }
 
+func ExampleField_SetLabelT() {
+	fld := aform.Must(aform.DefaultBooleanField("remember"))
+	fld.SetLabelT(map[language.Tag]string{language.English: "Remember me", language.French: "Se souvenir de moi"})
+	fld.SetLocale(language.French)
+	fmt.Println(fld.LabelTag())
+	// Output:
+	// <label for="id_remember">Se souvenir de moi</label>
+}
+
 func ExampleField_SetLabelSuffix() {
 	fld := aform.Must(aform.DefaultBooleanField("Remember me"))
 	fld.SetLabelSuffix(":")
@@ -1,5 +1,7 @@
 package aform
 
+import "golang.org/x/text/language"
+
 var ExportAttributableAttribute = Attributable.attribute
 var ExportRequiredError = requiredError
 
@@ -16,3 +18,52 @@ var ExportSelectLanguage = selectLanguage
 var ExportLanguages = languages
 
 var ExportBuildValidationChoicesRule = buildValidationChoicesRule
+var ExportSignature = signature
+var ExportSign = sign
+var ExportTimestampSigningPurpose = timestampSigningPurpose
+var ExportCSRFSigningPurpose = csrfSigningPurpose
+
+func ExportProtectionPurpose(fld *Field) string {
+	return fld.protectionPurpose()
+}
+
+var ExportIsRegisteredLanguage = isRegisteredLanguage
+var ExportLocaleChain = localeChain
+
+func ExportMemoryTokenStoreLen(s *MemoryTokenStore) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.tokens)
+}
+
+var ExportWizardSigningPurpose = wizardSigningPurpose
+
+func ExportUnregisterErrorCode(code string) {
+	errorRegistry.Lock()
+	defer errorRegistry.Unlock()
+	delete(errorRegistry.messages, code)
+}
+
+func ExportUnregisterLanguage(tag language.Tag) {
+	catalog.Lock()
+	defer catalog.Unlock()
+	if utLocale, ok := catalog.utLocales[tag.String()]; ok {
+		catalog.abandonedTranslators[utLocale] = true
+	}
+	delete(catalog.utLocales, tag.String())
+	delete(catalog.messages, tag)
+	for i, l := range languages {
+		if l == tag {
+			languages = append(languages[:i:i], languages[i+1:]...)
+			break
+		}
+	}
+}
+
+func ExportDeleteMessages(tag language.Tag, codes ...string) {
+	catalog.Lock()
+	defer catalog.Unlock()
+	for _, code := range codes {
+		delete(catalog.messages[tag], code)
+	}
+}
@@ -1,6 +1,8 @@
 package aform
 
 import (
+	"context"
+	"crypto/cipher"
 	"golang.org/x/text/language"
 )
 
@@ -21,6 +23,7 @@ const (
 	URLFieldType            = FieldType("URLField")
 	ChoiceFieldType         = FieldType("ChoiceField")
 	MultipleChoiceFieldType = FieldType("MultipleChoiceField")
+	CaptchaFieldType        = FieldType("CaptchaField")
 )
 
 // FieldOption describes a functional option for configuring a Field.
@@ -93,8 +96,10 @@ func WithGroupedChoiceOptions(label string, options []ChoiceFieldOption) FieldOp
 // Field is the type grouping features shared by all field types.
 type Field struct {
 	name             string
+	prefix           string
 	boundValues      []string
 	errors           []Error
+	warnings         []Error
 	optionGroups     []choiceFieldOptionGroup
 	fieldType        FieldType
 	widget           Widget
@@ -103,17 +108,29 @@ type Field struct {
 	errorCSSClass    string
 	attrs            tmplAttrs
 	label            string
+	labelT           localizedText
 	labelSuffix      string
 	isSafe           bool
 	helpText         string
+	helpTextT        localizedText
 	minLength        uint
 	maxLength        uint
 	notRequired      bool
 	disabled         bool
+	autofocus        bool
 	sanitizeFunc     func(string) string
 	validateFunc     func(string, bool) []Error
+	validateCtxFunc  ValidationContextFunc
+	validators       []Validator
+	stopOnFirstError bool
 	customErrors     map[string]Error
 	locale           language.Tag
+	captcha          CaptchaProvider
+	localized        localizedFormat
+	localizedRaw     bool
+	signingSecret    []byte
+	encryption       cipher.AEAD
+	ctx              context.Context
 }
 
 // Type returns the Field type.
@@ -41,11 +41,14 @@ func (fld *Field) SetLabelSuffix(labelSuffix string) {
 // CustomizeError replaces one built-in Error with err, if err
 // ErrorCoderTranslator.Code matches one of the existing Error code. Existing
 // Error codes are BooleanErrorCode, EmailErrorCode, ChoiceErrorCode,
-// MinLengthErrorCode, MaxLengthErrorCode, RequiredErrorCode and URLErrorCode.
-// If err ErrorCoderTranslator.Code is not from this list, it panics.
+// MinLengthErrorCode, MaxLengthErrorCode, RequiredErrorCode, URLErrorCode,
+// FieldsEqualErrorCode, AtLeastOneOfErrorCode, MutuallyExclusiveErrorCode,
+// CaptchaErrorCode, SignatureErrorCode, RegexErrorCode, NotInErrorCode and
+// IntegerErrorCode. Codes registered with RegisterErrorCode can be customized
+// too. If err ErrorCoderTranslator.Code is not from this list, it panics.
 func (fld *Field) CustomizeError(err ErrorCoderTranslator) {
 	e := errorWrapIfNotAsError(err)
-	if !slices.Contains(customizableErrors, e.Code()) {
+	if !slices.Contains(customizableErrors, e.Code()) && !isRegisteredErrorCode(e.Code()) {
 		panic(fmt.Sprintf("CustomizeError called on %s field with a nonexisting Error code: %s", fld.name, e.Code()))
 	}
 	if fld.customErrors == nil {
@@ -96,8 +99,8 @@ func (fld *Field) SetWidget(widget Widget) {
 }
 
 // SetLocale changes the locale used by the field to translate error
-// messages. To set the same locale to all fields in a form use
-// WithLocales.
+// messages, label, help text and choice labels. To set the same locale to
+// all fields in a form use WithLocales.
 func (fld *Field) SetLocale(locale language.Tag) {
 	fld.locale = locale
 }
@@ -132,6 +135,16 @@ type ValidationFunc func(string, bool) []Error
 // function that itself has current validation function as parameter and must
 // return the new validation function. Default validation function depends on
 // the field type.
+//
+// If a function has been set with SetValidateContextFunc, current calls it
+// with the context of the form and the new function replaces it.
 func (fld *Field) SetValidateFunc(update func(current ValidationFunc) (new ValidationFunc)) {
-	fld.validateFunc = update(fld.validateFunc)
+	current := fld.validateFunc
+	if contextFunc := fld.validateCtxFunc; contextFunc != nil {
+		current = func(value string, required bool) []Error {
+			return contextFunc(fld.context(), value, required)
+		}
+		fld.validateCtxFunc = nil
+	}
+	fld.validateFunc = update(current)
 }
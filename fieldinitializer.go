@@ -3,9 +3,11 @@ package aform
 // SetLabel overrides the default label of the Field. By default, the label is
 // the name of the Field given as parameter to a Field creation function. The
 // label is HTML-escaped. To alter more the for= attribute or to completely
-// remove the tag <label> use SetAutoID.
+// remove the tag <label> use SetAutoID. It replaces the label set with
+// SetLabelT or SetLabelKey.
 func (fld *Field) SetLabel(label string) {
 	fld.label = label
+	fld.labelT = localizedText{}
 }
 
 // MarkSafe marks the field label as safe for HTML. It means the label
@@ -15,8 +17,10 @@ func (fld *Field) MarkSafe() {
 }
 
 // SetHelpText adds a help text to the Field. Help text is not HTML-escaped.
+// It replaces the help text set with SetHelpTextT or SetHelpTextKey.
 func (fld *Field) SetHelpText(help string) {
 	fld.helpText = help
+	fld.helpTextT = localizedText{}
 }
 
 // SetNotRequired sets the Field as not required. By default,
@@ -10,9 +10,14 @@ func (fld *Field) Name() string {
 }
 
 // HTMLName returns the field's name transformed to be the value of the HTML
-// name attribute.
+// name attribute. If the form has a prefix, it is added at the beginning.
 func (fld *Field) HTMLName() string {
-	return normalizedNameForField(fld)
+	return prefixedNameForField(fld)
+}
+
+// Prefix returns the prefix set by the form with WithPrefix.
+func (fld *Field) Prefix() string {
+	return fld.prefix
 }
 
 // AutoID returns the auto ID set with SetAutoID.
@@ -53,7 +58,7 @@ func (fld *Field) Required() bool {
 
 // HasHelpText returns true if a help text has been added to the field.
 func (fld *Field) HasHelpText() bool {
-	return len(fld.helpText) > 0
+	return len(fld.localizedHelpText()) > 0
 }
 
 // HasErrors returns true if an input is bound to the field and there is a
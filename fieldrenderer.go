@@ -34,8 +34,9 @@ func (fld *Field) labelOrLegendTag(tag string) template.HTML {
 	if len(classes) > 0 {
 		attrs["class"] = strings.Join(classes, " ")
 	}
-	safeLbl := newSafeLabel(useTag, fld.label, fld.labelSuffix, attrs)
-	lbl := newLabel(useTag, fld.label, fld.labelSuffix, attrs)
+	label := fld.localizedLabel()
+	safeLbl := newSafeLabel(useTag, label, fld.labelSuffix, attrs)
+	lbl := newLabel(useTag, label, fld.labelSuffix, attrs)
 	switch tag {
 	case "label":
 		if fld.isSafe {
@@ -54,6 +55,9 @@ func (fld *Field) labelOrLegendTag(tag string) template.HTML {
 
 // Widget renders the widget.
 func (fld *Field) Widget() template.HTML {
+	if fld.captcha != nil {
+		return fld.widgetCaptcha(fld.widgetCSSClassList())
+	}
 	switch fld.widget {
 	case TextInput, EmailInput, URLInput, PasswordInput, HiddenInput, TextArea, CheckboxInput:
 		return fld.widgetInput(fld.widgetCSSClassList())
@@ -72,17 +76,19 @@ func (fld *Field) widgetInput(classes []string) template.HTML {
 	attrs := attributesForField(fld, classes)
 	value := ""
 	if len(fld.boundValues) > 0 {
-		value = fld.boundValues[0]
+		value = fld.localizeValue(fld.boundValues[0])
 	}
 	if selectedAttr, ok := fld.widget.selectedAttr(valueToBool(value)); ok {
 		attrs[selectedAttr.n] = selectedAttr.v
 	}
 	if fld.widget.noAttrValue() {
 		value = ""
+	} else if fld.isProtected() {
+		value = fld.protect(value)
 	}
 	return mustInputTemplate(&widgetInput{
 		Type:  fld.widget,
-		Name:  normalizedNameForField(fld),
+		Name:  prefixedNameForField(fld),
 		Value: value,
 		Attrs: attrs,
 	})
@@ -106,7 +112,7 @@ func (fld *Field) widgetChoice(classes []string) template.HTML {
 	}(fld.widget.isMultiChoice())
 	return mustChoiceTemplate(&widgetChoice{
 		Type:   fld.widget,
-		Name:   normalizedNameForField(fld),
+		Name:   prefixedNameForField(fld),
 		Values: values,
 		Groups: fld.widgetGroups(values),
 		Attrs:  attrs,
@@ -114,7 +120,15 @@ func (fld *Field) widgetChoice(classes []string) template.HTML {
 }
 
 func (fld *Field) widgetGroups(selected []string) []map[string][]widgetOption {
-	return fieldGroupsToWidgetGroups(fld.optionGroups, fld.widget.optionWidget(), normalizedIDForField(fld), normalizedNameForField(fld), selected)
+	groups := fieldGroupsToWidgetGroups(fld.optionGroups, fld.widget.optionWidget(), normalizedIDForField(fld), prefixedNameForField(fld), selected, fld.locale)
+	if fld.autofocus && fld.widget.isMultipleInput() && len(groups) > 0 {
+		for _, options := range groups[0] {
+			if len(options) > 0 {
+				options[0].Attrs["autofocus"] = ""
+			}
+		}
+	}
+	return groups
 }
 
 func attributesForField(fld *Field, classes []string) tmplAttrs {
@@ -134,14 +148,20 @@ func attributesForField(fld *Field, classes []string) tmplAttrs {
 	if fld.HasErrors() {
 		attrs["aria-invalid"] = "true"
 	}
+	if fld.autofocus && !fld.widget.isMultipleInput() {
+		attrs["autofocus"] = ""
+	}
 	if hasID(fld) {
 		var ariaDescribedBy []string
-		if len(fld.helpText) > 0 {
+		if fld.HasHelpText() {
 			ariaDescribedBy = append(ariaDescribedBy, normalizedDescribedByIDForHelpText(fld))
 		}
 		if fld.HasErrors() {
 			ariaDescribedBy = append(ariaDescribedBy, normalizedDescribedByIDErrList(fld, len(fld.errors))...)
 		}
+		if fld.HasWarnings() {
+			ariaDescribedBy = append(ariaDescribedBy, normalizedDescribedByIDWarningList(fld, len(fld.warnings))...)
+		}
 		if len(ariaDescribedBy) > 0 {
 			attrs["aria-describedby"] = strings.Join(ariaDescribedBy, " ")
 		}
@@ -178,7 +198,7 @@ func (fld *Field) Errors() template.HTML {
 			attrs["id"] = normalizedDescribedByIDForErr(fld, i)
 		}
 		list[i] = tmplError{
-			Text:  err.Translate(fld.locale.String()),
+			Text:  fld.TranslateError(err),
 			Attrs: attrs,
 		}
 	}
@@ -191,7 +211,8 @@ func (fld *Field) Errors() template.HTML {
 // HelpText renders the help text in a <span> tag. CSS class helptext and an ID
 // generated from the field ID are set on the <span> tag.
 func (fld *Field) HelpText() template.HTML {
-	if len(fld.helpText) == 0 {
+	helpText := fld.localizedHelpText()
+	if len(helpText) == 0 {
 		return ""
 	}
 	attrs := map[string]string{"class": "helptext"}
@@ -199,7 +220,7 @@ func (fld *Field) HelpText() template.HTML {
 		attrs["id"] = normalizedDescribedByIDForHelpText(fld)
 	}
 	return mustHelpTextTemplate(&tmplHelpText{
-		Text:  template.HTML(fld.helpText),
+		Text:  template.HTML(helpText),
 		Attrs: attrs,
 	})
 }
@@ -1,16 +1,19 @@
 package aform
 
 import (
+	"context"
 	"fmt"
 	"golang.org/x/text/language"
 	"html/template"
 	"net/http"
+	"time"
 )
 
 // Form represents a form.
 type Form struct {
 	fields           []fieldInterface
 	autoID           string
+	prefix           string
 	requiredCSSClass string
 	errorCSSClass    string
 	labelSuffix      string
@@ -21,16 +24,39 @@ type Form struct {
 	cleanedData      map[string][]string
 	errors           map[string][]Error
 	cleanFunc        func(*Form)
+	rules            []formRule
 	locales          []language.Tag
+	locale           language.Tag
+	localeResolvers  []LocaleResolver
+	strict           bool
+	maxValues        uint
+	managementKeys   []string
+	bindErrors       []Error
+	maxBodyBytes     int64
+	maxMemory        int64
+	req              *http.Request
+	csrfSecret       []byte
+	honeypot         string
+	timestampSecret  []byte
+	timestampMin     time.Duration
+	timestampMax     time.Duration
+	protectionData   map[string]string
+	tampered         map[string]bool
+	tokenStore       TokenStore
+	issuedToken      string
+	tokenRejected    bool
+	restored         bool
+	ctx              context.Context
+	summaryFocus     ErrorSummaryFocus
 }
 
 // FormOption describes a functional option for configuring a Form.
 type FormOption func(*Form) error
 
 // FormPointerOrFieldPointer defines a union type to allow the usage of the helper
-// function Must with forms and all fields types.
+// function Must with forms, formsets, wizards and all fields types.
 type FormPointerOrFieldPointer interface {
-	*Form | *BooleanField | *EmailField | *CharField | *ChoiceField | *MultipleChoiceField
+	*Form | *FormSet | *Wizard | *BooleanField | *EmailField | *CharField | *ChoiceField | *MultipleChoiceField | *CaptchaField
 }
 
 // Must is a helper that wraps a call to a function returning (*Form, error)
@@ -48,33 +74,91 @@ func Must[FormPtrFieldPtr FormPointerOrFieldPointer](f FormPtrFieldPtr, err erro
 // New returns a Form.
 func New(opts ...FormOption) (*Form, error) {
 	f := &Form{
-		autoID:    defaultAutoID,
-		cleanFunc: func(f *Form) {},
+		autoID:       defaultAutoID,
+		cleanFunc:    func(f *Form) {},
+		locale:       defaultLanguage,
+		maxBodyBytes: defaultMaxBodyBytes,
+		maxMemory:    defaultMaxMemory,
 	}
 	for _, opt := range opts {
 		if err := opt(f); err != nil {
 			return nil, err
 		}
 	}
+	if err := f.checkRules(); err != nil {
+		return nil, err
+	}
 	return f, nil
 }
 
 // AsDiv renders the form as a list of <div> tags, with each <div> containing
-// one field.
+// one field. If the form is validated, errors not attached to a field are
+// rendered first in a <ul> tag with CSS classes errorlist and nonfield. If the
+// form is created with WithCSRF, WithTimestamp, WithOneTimeToken or
+// WithHoneypot, their hidden inputs are rendered before the fields.
 func (f *Form) AsDiv() template.HTML {
 	return mustFormAsDivTemplate(f)
 }
 
+// nonFieldErrorsForTemplate returns the translated non-field errors ready to
+// be rendered by the errors template, or nil if there is none.
+func (f *Form) nonFieldErrorsForTemplate() *tmplErrors {
+	if !f.validated || len(f.errors[NonFieldErrorsKey]) == 0 {
+		return nil
+	}
+	errs := f.errors[NonFieldErrorsKey]
+	list := make([]tmplError, len(errs))
+	for i, err := range errs {
+		list[i] = tmplError{
+			Text:  err.Translate(f.locale.String()),
+			Attrs: tmplAttrs{},
+		}
+	}
+	return &tmplErrors{
+		List:  list,
+		Attrs: map[string]string{"class": "errorlist nonfield"},
+	}
+}
+
 // BindRequest binds req form data to the Form. After a first binding, following
 // bindings are ignored. If you want to bind new data, you should create another
 // identical Form to do it. Data is bound but not validated.
 // Validation is done when IsValid, CleanedData or Errors are called.
-// Error messages are localized according to Accept-Language header. To modify
-// this behavior use directly BindData.
-func (f *Form) BindRequest(req *http.Request) {
-	acceptLanguage := req.Header.Get("Accept-Language")
-	f.BindData(req.Form, acceptLanguage)
-	return
+// Error messages are localized according to the locale resolved with the
+// resolvers set with WithLocaleResolvers, by default the Accept-Language
+// header. To modify this behavior use directly BindData.
+//
+// URL query and body are parsed by BindRequest. Bodies encoded as
+// application/x-www-form-urlencoded and multipart/form-data are supported.
+// Body size is limited with WithMaxBodyBytes and multipart memory usage with
+// WithMaxMemory. If parsing fails, an error is returned and the form is not
+// bound. If req has already been parsed, it is not parsed again and limits
+// don't apply.
+//
+// The context of req is kept for validation. It is given to the functions set
+// with Field.SetValidateContextFunc and SetCleanContextFunc. If it is
+// canceled, validation stops and an error with the code CanceledErrorCode is
+// added to the non-field errors.
+func (f *Form) BindRequest(req *http.Request) error {
+	if f.bound {
+		return nil
+	}
+	if err := parseRequest(req, f.maxBodyBytes, f.maxMemory); err != nil {
+		return err
+	}
+	f.bindRequestData(req, resolveLocales(req, f.localeResolvers)...)
+	return nil
+}
+
+// bindRequestData binds the parsed form data of req to the Form and keeps req
+// for validation. Data checked in strict binding mode is req.PostForm.
+func (f *Form) bindRequestData(req *http.Request, langs ...string) {
+	if f.bound {
+		return
+	}
+	f.req = req
+	f.ctx = req.Context()
+	f.bindData(req.Form, req.PostForm, langs...)
 }
 
 // BindData binds data to the Form. After a first binding, following bindings
@@ -82,28 +166,84 @@ func (f *Form) BindRequest(req *http.Request) {
 // identical Form to do it. Data is bound but not validated.
 // Validation is done when IsValid, CleanedData or Errors are called.
 func (f *Form) BindData(data map[string][]string, langs ...string) {
+	f.bindData(data, data, langs...)
+}
+
+// bindData binds data to the Form. strictData is the data checked in strict
+// binding mode.
+func (f *Form) bindData(data, strictData map[string][]string, langs ...string) {
 	if f.bound {
 		return
 	}
 	f.bound = true
 	filteredData := map[string][]string{}
-	for _, name := range f.fieldNames {
-		values, ok := data[name]
-		if ok {
-			filteredData[name] = values
+	f.tampered = map[string]bool{}
+	for _, fld := range f.fields {
+		values, ok := data[prefixedNameForField(fld)]
+		if !ok {
+			continue
+		}
+		if values, ok = fld.field().unprotect(values); !ok {
+			f.tampered[normalizedNameForField(fld)] = true
+			continue
 		}
+		filteredData[normalizedNameForField(fld)] = values
 	}
 	f.boundData = filteredData
-	propagateLocalesIfNotEmpty(f.fields, []language.Tag{selectLanguage(f.locales, langs...)})
+	f.protectionData = map[string]string{}
+	for _, name := range f.protectionFieldNames() {
+		f.protectionData[name] = firstValue(data[name])
+	}
+	if f.strict {
+		f.bindErrors = f.strictBindingErrors(strictData)
+	}
+	f.SelectLocale(langs...)
 	return
 }
 
+// SelectLocale selects the locale of the form among the locales set with
+// WithLocales. langs are matched like the Accept-Language header. The locale
+// is used to translate error messages, labels, help texts and choice labels.
+// BindData selects the locale too, SelectLocale is useful to render an
+// unbound form in the language of the user.
+func (f *Form) SelectLocale(langs ...string) {
+	f.locale = selectLanguage(f.locales, langs...)
+	propagateLocalesIfNotEmpty(f.fields, []language.Tag{f.locale})
+}
+
 // IsBound returns true if the form is already bound to data
 // with BindRequest or BindData.
 func (f *Form) IsBound() bool {
 	return f.bound
 }
 
+// HasChanged returns true if at least one bound value is different from the
+// initial value given to the field creation function. An unbound form has
+// never changed. See ChangedData for details.
+func (f *Form) HasChanged() bool {
+	return len(f.ChangedData()) > 0
+}
+
+// ChangedData returns the normalized names of the fields whose bound value
+// is different from the initial value, in the fields order. Bound values are
+// sanitized before the comparison, and each field type compares them with
+// its own semantics: a BooleanField bound with "true" has not changed if its
+// initial value is true, and a MultipleChoiceField ignores values order. An
+// unbound form returns an empty list.
+func (f *Form) ChangedData() []string {
+	changed := []string{}
+	if !f.IsBound() {
+		return changed
+	}
+	for _, fld := range f.fields {
+		nName := normalizedNameForField(fld)
+		if fld.hasChanged(f.boundData[nName]) {
+			changed = append(changed, nName)
+		}
+	}
+	return changed
+}
+
 // WithBooleanField returns a FormOption that adds the BooleanField fld
 // to the list of fields.
 func WithBooleanField(fld *BooleanField) FormOption {
@@ -144,18 +284,166 @@ func WithMultipleChoiceField(fld *MultipleChoiceField) FormOption {
 	}
 }
 
+// WithCaptchaField returns a FormOption that adds the CaptchaField fld
+// to the list of fields.
+func WithCaptchaField(fld *CaptchaField) FormOption {
+	return func(f *Form) error {
+		return f.addField(fld)
+	}
+}
+
 func (f *Form) addField(fld fieldInterface) error {
 	f.fields = append(f.fields, fld)
 	f.fieldNames = append(f.fieldNames, normalizedNameForField(fld))
+	propagatePrefix([]fieldInterface{fld}, f.prefix)
 	propagateLabelSuffix([]fieldInterface{fld}, f.labelSuffix)
 	propagateRequiredCSSClassIfNotEmpty([]fieldInterface{fld}, f.requiredCSSClass)
 	propagateErrorCSSClassIfNotEmpty([]fieldInterface{fld}, f.errorCSSClass)
-	propagateLocalesIfNotEmpty([]fieldInterface{fld}, f.locales)
+	propagateLocalesIfNotEmpty([]fieldInterface{fld}, []language.Tag{f.locale})
 	return propagateAutoIDIfNotDefault([]fieldInterface{fld}, f.autoID)
 }
 
+// FormField is the interface implemented by all the field types:
+// BooleanField, CharField, EmailField, ChoiceField, MultipleChoiceField and
+// CaptchaField.
+// It is used to add fields to an existing Form with AddField or
+// InsertFieldAfter.
+type FormField interface {
+	fieldInterface
+}
+
+// AddField adds fld at the end of the list of fields. Like fields added with
+// FormOption functions such as WithCharField, fld gets form settings: auto ID,
+// prefix, CSS classes, label suffix and locales. It returns an error if
+// the form is bound or if a field with the same name already exists.
+func (f *Form) AddField(fld FormField) error {
+	if err := f.checkFieldsCanChange(); err != nil {
+		return err
+	}
+	if err := f.checkFieldNameIsFree(fld); err != nil {
+		return err
+	}
+	return f.addField(fld)
+}
+
+// InsertFieldAfter inserts fld just after the field named after. fld gets
+// form settings like with AddField. It returns an error if the form is bound,
+// if there is no field named after or if a field with the same name as fld
+// already exists.
+func (f *Form) InsertFieldAfter(after string, fld FormField) error {
+	if err := f.checkFieldsCanChange(); err != nil {
+		return err
+	}
+	if err := f.checkFieldNameIsFree(fld); err != nil {
+		return err
+	}
+	index, err := f.fieldIndex(after)
+	if err != nil {
+		return err
+	}
+	if err := f.addField(fld); err != nil {
+		return err
+	}
+	fields := make([]fieldInterface, 0, len(f.fields))
+	fields = append(fields, f.fields[:index+1]...)
+	fields = append(fields, fld)
+	fields = append(fields, f.fields[index+1:len(f.fields)-1]...)
+	f.setFields(fields)
+	return nil
+}
+
+// RemoveField removes the field named field. It returns an error if the form
+// is bound, if there is no field with this name or if the field is used by a
+// rule like FieldsEqual.
+func (f *Form) RemoveField(field string) error {
+	if err := f.checkFieldsCanChange(); err != nil {
+		return err
+	}
+	index, err := f.fieldIndex(field)
+	if err != nil {
+		return err
+	}
+	nName := normalizedNameForField(f.fields[index])
+	for _, rule := range f.rules {
+		for _, name := range rule.fields {
+			if normalizedName(name) == nName {
+				return fmt.Errorf("you can't remove field %s used by %s", field, rule.name)
+			}
+		}
+	}
+	fields := make([]fieldInterface, 0, len(f.fields)-1)
+	fields = append(fields, f.fields[:index]...)
+	fields = append(fields, f.fields[index+1:]...)
+	f.setFields(fields)
+	return nil
+}
+
+// OrderFields reorders the fields. Fields named in names come first in the
+// order of names. Fields not listed keep their relative order after them.
+// Rendering order follows the new order. It returns an error if the form is
+// bound or if a name doesn't match any field.
+func (f *Form) OrderFields(names []string) error {
+	if err := f.checkFieldsCanChange(); err != nil {
+		return err
+	}
+	fields := make([]fieldInterface, 0, len(f.fields))
+	ordered := map[int]bool{}
+	for _, name := range names {
+		index, err := f.fieldIndex(name)
+		if err != nil {
+			return err
+		}
+		if ordered[index] {
+			continue
+		}
+		ordered[index] = true
+		fields = append(fields, f.fields[index])
+	}
+	for i, fld := range f.fields {
+		if !ordered[i] {
+			fields = append(fields, fld)
+		}
+	}
+	f.setFields(fields)
+	return nil
+}
+
+func (f *Form) checkFieldsCanChange() error {
+	if f.bound {
+		return fmt.Errorf("you can't change the fields of a bound form")
+	}
+	return nil
+}
+
+func (f *Form) checkFieldNameIsFree(fld fieldInterface) error {
+	if _, err := f.fieldIndex(fld.Name()); err == nil {
+		return fmt.Errorf("a field with this name %s already exists", fld.Name())
+	}
+	return nil
+}
+
+func (f *Form) fieldIndex(field string) (int, error) {
+	nName := normalizedName(field)
+	for i, fld := range f.fields {
+		if normalizedNameForField(fld) == nName {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("no field with this name %s", field)
+}
+
+// setFields replaces the list of fields and keeps the list of field names
+// consistent with it.
+func (f *Form) setFields(fields []fieldInterface) {
+	f.fields = fields
+	f.fieldNames = make([]string, len(fields))
+	for i, fld := range fields {
+		f.fieldNames[i] = normalizedNameForField(fld)
+	}
+}
+
 // Fields returns the list of fields added to the form. First added comes
-// first.
+// first unless fields are reordered with InsertFieldAfter or OrderFields.
 func (f *Form) Fields() []*Field {
 	fields := make([]*Field, len(f.fields))
 	for i, fld := range f.fields {
@@ -177,7 +465,7 @@ func (f *Form) FieldByName(field string) (*Field, error) {
 func (f *Form) internalFieldByName(field string) (fieldInterface, error) {
 	nName := normalizedName(field)
 	for _, fld := range f.fields {
-		if fld.HTMLName() == field || fld.HTMLName() == nName {
+		if normalizedNameForField(fld) == field || normalizedNameForField(fld) == nName {
 			return fld, nil
 		}
 	}
@@ -231,6 +519,25 @@ func propagateAutoIDIfNotDefault(fields []fieldInterface, autoID string) error {
 	return nil
 }
 
+// WithPrefix returns a FormOption that adds prefix at the beginning of the
+// HTML name and ID of all the fields. Prefix and field name are separated by a
+// dash. e.g. with prefix "form-0" the field "Name" is rendered with the
+// attribute name="form-0-name". Prefixes allow several forms to be bound from
+// the same request. CleanedData and Errors keys are not prefixed.
+func WithPrefix(prefix string) FormOption {
+	return func(f *Form) error {
+		f.prefix = prefix
+		propagatePrefix(f.fields, prefix)
+		return nil
+	}
+}
+
+func propagatePrefix(fields []fieldInterface, prefix string) {
+	for _, fld := range fields {
+		fld.field().prefix = prefix
+	}
+}
+
 // WithLabelSuffix returns a FormOption that set a suffix to labels. Label
 // suffix is added to all fields.
 func WithLabelSuffix(labelSuffix string) FormOption {
@@ -298,23 +605,30 @@ func propagateErrorCSSClass(fields []fieldInterface, class string) {
 }
 
 // WithLocales returns a FormOption that sets the list of locales used to
-// translate error messages. Default locale is "en".
+// translate error messages, labels, help texts and choice labels. Default
+// locale is "en". Available locales are "en", "fr", "de", "es", "it", "pt",
+// "nl" and the languages registered with RegisterLanguage.
 func WithLocales(locales []language.Tag) FormOption {
 	return func(f *Form) error {
 		f.locales = locales
+		f.locale = firstLocale(locales)
 		propagateLocalesIfNotEmpty(f.fields, locales)
 		return nil
 	}
 }
 
 func propagateLocalesIfNotEmpty(fields []fieldInterface, locales []language.Tag) {
-	firstLocale := defaultLanguage
-	if len(locales) > 0 {
-		firstLocale = locales[0]
-	}
+	locale := firstLocale(locales)
 	for _, fld := range fields {
-		fld.SetLocale(firstLocale)
+		fld.SetLocale(locale)
+	}
+}
+
+func firstLocale(locales []language.Tag) language.Tag {
+	if len(locales) > 0 {
+		return locales[0]
 	}
+	return defaultLanguage
 }
 
 // CleanedData maps a field normalized name to the list of bound data after validation
@@ -432,3 +432,156 @@ func TestForm_AsDiv_withMultipleChoiceFieldAndInvalidData(t *testing.T) {
 </div>`)
 	a.Equal(want, f.AsDiv())
 }
+
+func TestForm_WithPrefix(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithPrefix("applicant"), aform.WithCharField(aform.Must(aform.DefaultCharField("First name")))))
+	a.Equal(`
+<div><label for="id_applicant-first_name">First name</label><input type="text" name="applicant-first_name" id="id_applicant-first_name" maxlength="256" required></div>`, string(f.AsDiv()))
+	f.BindData(map[string][]string{"applicant-first_name": {"Jane"}, "first_name": {"John"}})
+	a.True(f.IsValid())
+	a.Equal("Jane", f.CleanedData().Get("first_name"))
+	fld, err := f.FieldByName("first_name")
+	a.NoError(err)
+	a.Equal("applicant-first_name", fld.HTMLName())
+}
+
+func TestForm_ChangedData(t *testing.T) {
+	newForm := func() *aform.Form {
+		return aform.Must(aform.New(
+			aform.WithCharField(aform.Must(aform.NewCharField("Name", "Jane", "", 0, 0))),
+			aform.WithBooleanField(aform.Must(aform.NewBooleanField("Remote", true))),
+			aform.WithChoiceField(aform.Must(aform.NewChoiceField("Contract", "cdi", aform.WithChoiceOptions([]aform.ChoiceFieldOption{{Value: "cdi"}, {Value: "cdd"}})))),
+			aform.WithMultipleChoiceField(aform.Must(aform.NewMultipleChoiceField("Skills", []string{"go", "sql"}, aform.WithChoiceOptions([]aform.ChoiceFieldOption{{Value: "go"}, {Value: "sql"}, {Value: "js"}})))),
+		))
+	}
+	tests := []struct {
+		name string
+		data map[string][]string
+		want []string
+	}{
+		{
+			name: "nothing changed",
+			data: map[string][]string{"name": {" Jane "}, "remote": {"true"}, "contract": {"cdi"}, "skills": {"sql", "go"}},
+			want: []string{},
+		},
+		{
+			name: "everything changed",
+			data: map[string][]string{"name": {"John"}, "contract": {"cdd"}, "skills": {"go"}},
+			want: []string{"name", "remote", "contract", "skills"},
+		},
+		{
+			name: "multiple choice with a new value",
+			data: map[string][]string{"name": {"Jane"}, "remote": {"on"}, "contract": {"cdi"}, "skills": {"go", "sql", "js"}},
+			want: []string{"skills"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			f := newForm()
+			f.BindData(tt.data)
+			a.Equal(tt.want, f.ChangedData())
+			a.Equal(len(tt.want) > 0, f.HasChanged())
+		})
+	}
+}
+
+func TestForm_HasChanged_unbound(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Name", "Jane", "", 0, 0)))))
+	a.False(f.HasChanged())
+	a.Empty(f.ChangedData())
+}
+
+func testFieldNames(f *aform.Form) []string {
+	var names []string
+	for _, fld := range f.Fields() {
+		names = append(names, fld.HTMLName())
+	}
+	return names
+}
+
+func TestForm_AddField(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithPrefix("p"),
+		aform.WithLabelSuffix(":"),
+		aform.WithRequiredCSSClass("required"),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("First"))),
+	))
+	a.NoError(f.AddField(aform.Must(aform.DefaultCharField("Second"))))
+	a.Equal([]string{"p-first", "p-second"}, testFieldNames(f))
+	fld, err := f.FieldByName("second")
+	a.NoError(err)
+	a.Equal(`<div class="required"><label class="required" for="id_p-second">Second:</label><input type="text" name="p-second" id="id_p-second" maxlength="256" required></div>`, string(fld.AsDiv()))
+	a.EqualError(f.AddField(aform.Must(aform.DefaultCharField("Second"))), "a field with this name Second already exists")
+}
+
+func TestForm_InsertFieldAfter(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithCharField(aform.Must(aform.DefaultCharField("First"))),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Third"))),
+	))
+	a.NoError(f.InsertFieldAfter("first", aform.Must(aform.DefaultCharField("Second"))))
+	a.NoError(f.InsertFieldAfter("third", aform.Must(aform.DefaultCharField("Fourth"))))
+	a.Equal([]string{"first", "second", "third", "fourth"}, testFieldNames(f))
+	a.EqualError(f.InsertFieldAfter("unknown", aform.Must(aform.DefaultCharField("Fifth"))), "no field with this name unknown")
+	a.Equal([]string{"first", "second", "third", "fourth"}, testFieldNames(f))
+}
+
+func TestForm_RemoveField(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithCharField(aform.Must(aform.DefaultCharField("First"))),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Second"))),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Third"))),
+		aform.FieldsEqual("Second", "Third"),
+	))
+	a.NoError(f.RemoveField("First"))
+	a.Equal([]string{"second", "third"}, testFieldNames(f))
+	a.EqualError(f.RemoveField("First"), "no field with this name First")
+	a.EqualError(f.RemoveField("third"), "you can't remove field third used by FieldsEqual")
+	f.BindData(map[string][]string{"first": {"ignored"}, "second": {"a"}, "third": {"a"}})
+	a.True(f.IsValid())
+	a.Equal(aform.CleanedData{"second": {"a"}, "third": {"a"}}, f.CleanedData())
+}
+
+func TestForm_OrderFields(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithCharField(aform.Must(aform.DefaultCharField("First"))),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Second"))),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Third"))),
+	))
+	a.NoError(f.OrderFields([]string{"third", "First"}))
+	a.Equal([]string{"third", "first", "second"}, testFieldNames(f))
+	a.EqualError(f.OrderFields([]string{"fourth"}), "no field with this name fourth")
+}
+
+func TestForm_fieldsChangesRejectedWhenBound(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("First")))))
+	f.BindData(map[string][]string{})
+	a.EqualError(f.AddField(aform.Must(aform.DefaultCharField("Second"))), "you can't change the fields of a bound form")
+	a.EqualError(f.InsertFieldAfter("first", aform.Must(aform.DefaultCharField("Second"))), "you can't change the fields of a bound form")
+	a.EqualError(f.RemoveField("first"), "you can't change the fields of a bound form")
+	a.EqualError(f.OrderFields([]string{"first"}), "you can't change the fields of a bound form")
+}
+
+func TestForm_AddField_selectedLocale(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithLocales([]language.Tag{language.English, language.French}),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("First"))),
+	))
+	f.SelectLocale("fr")
+	a.NoError(f.AddField(aform.Must(aform.DefaultCharField("Second", aform.WithLabelT(map[language.Tag]string{
+		language.English: "Second",
+		language.French:  "Deuxième",
+	})))))
+	fld, err := f.FieldByName("second")
+	a.NoError(err)
+	a.Equal(`<div><label for="id_second">Deuxième</label><input type="text" name="second" id="id_second" maxlength="256" required></div>`, string(fld.AsDiv()))
+}
@@ -0,0 +1,253 @@
+package aform
+
+import (
+	"fmt"
+	"golang.org/x/exp/slices"
+	"golang.org/x/text/language"
+	"strings"
+)
+
+// formRule is a validation rule involving several fields. Rules are applied
+// after fields validation and before the form clean function.
+type formRule struct {
+	name   string
+	fields []string
+	apply  func(f *Form)
+}
+
+func (f *Form) addRule(rule formRule) error {
+	if len(rule.fields) == 0 {
+		return fmt.Errorf("%s must have at least one field", rule.name)
+	}
+	f.rules = append(f.rules, rule)
+	return nil
+}
+
+// checkRules returns an error if a rule refers to a field that doesn't
+// exist. Rules are checked once all the options are applied because fields
+// can be added after a rule.
+func (f *Form) checkRules() error {
+	for _, rule := range f.rules {
+		for _, name := range rule.fields {
+			if _, err := f.internalFieldByName(name); err != nil {
+				return fmt.Errorf("%s: %w", rule.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *Form) applyRules() {
+	for _, rule := range f.rules {
+		rule.apply(f)
+	}
+}
+
+// RequiredIf returns a FormOption that makes field required when the cleaned
+// data of otherField contains value. e.g. a company name required if the
+// employment type is "contractor":
+//
+//	RequiredIf("Company name", "Employment type", "contractor")
+//
+// field should be created with IsNotRequired. The error added to field has
+// the code RequiredErrorCode.
+func RequiredIf(field, otherField, value string) FormOption {
+	return func(f *Form) error {
+		return f.addRule(formRule{
+			name:   "RequiredIf",
+			fields: []string{field, otherField},
+			apply: func(f *Form) {
+				if !slices.Contains(f.cleanedData[normalizedName(otherField)], value) {
+					return
+				}
+				if f.isFilledOrInvalid(field) {
+					return
+				}
+				f.addRuleError(field, requiredError)
+			},
+		})
+	}
+}
+
+// FieldsEqual returns a FormOption that validates fields a and b have the same
+// cleaned data. e.g. a password and its confirmation. The error is added to b
+// with the code FieldsEqualErrorCode. It is not added if a or b is invalid.
+func FieldsEqual(a, b string) FormOption {
+	return func(f *Form) error {
+		return f.addRule(formRule{
+			name:   "FieldsEqual",
+			fields: []string{a, b},
+			apply: func(f *Form) {
+				aName, bName := normalizedName(a), normalizedName(b)
+				if f.errors[aName] != nil || f.errors[bName] != nil {
+					return
+				}
+				if slices.Equal(f.cleanedData[aName], f.cleanedData[bName]) {
+					return
+				}
+				f.addRuleError(b, fieldsEqualError(f.ruleFields(a)))
+			},
+		})
+	}
+}
+
+// AtLeastOneOf returns a FormOption that validates at least one of the fields
+// is filled. fields should be created with IsNotRequired. The error is added
+// to the first field with the code AtLeastOneOfErrorCode.
+func AtLeastOneOf(fields ...string) FormOption {
+	return func(f *Form) error {
+		return f.addRule(formRule{
+			name:   "AtLeastOneOf",
+			fields: fields,
+			apply: func(f *Form) {
+				for _, field := range fields {
+					if f.isFilledOrInvalid(field) {
+						return
+					}
+				}
+				f.addRuleError(fields[0], atLeastOneOfError(f.ruleFields(fields...)))
+			},
+		})
+	}
+}
+
+// MutuallyExclusive returns a FormOption that validates at most one of the
+// fields is filled. fields should be created with IsNotRequired. The error is
+// added to each filled field with the code MutuallyExclusiveErrorCode.
+func MutuallyExclusive(fields ...string) FormOption {
+	return func(f *Form) error {
+		return f.addRule(formRule{
+			name:   "MutuallyExclusive",
+			fields: fields,
+			apply: func(f *Form) {
+				var filled []string
+				for _, field := range fields {
+					if f.isFilled(field) {
+						filled = append(filled, field)
+					}
+				}
+				if len(filled) < 2 {
+					return
+				}
+				err := mutuallyExclusiveError(f.ruleFields(fields...))
+				for _, field := range filled {
+					f.addRuleError(field, err)
+				}
+			},
+		})
+	}
+}
+
+// addRuleError adds err to field with AddError. If err code has been
+// customized with Field.CustomizeError, the customized error is added instead.
+func (f *Form) addRuleError(field string, err Error) {
+	fld, e := f.internalFieldByName(field)
+	if e != nil {
+		return
+	}
+	_ = f.AddError(field, customizeErrors([]Error{err}, fld.field().customErrors)[0])
+}
+
+// isFilled returns true if at least one non-empty value is bound to field.
+// A BooleanField is filled if its value is true.
+func (f *Form) isFilled(field string) bool {
+	fld, err := f.internalFieldByName(field)
+	if err != nil {
+		return false
+	}
+	for _, value := range f.boundData[normalizedNameForField(fld)] {
+		value = fld.field().sanitize(value)
+		if fld.Type() == BooleanFieldType {
+			if valueToBool(value) {
+				return true
+			}
+			continue
+		}
+		if len(value) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Form) isFilledOrInvalid(field string) bool {
+	return f.isFilled(field) || f.errors[normalizedName(field)] != nil
+}
+
+// ruleFields returns the fields of a rule error.
+func (f *Form) ruleFields(fields ...string) []*Field {
+	output := make([]*Field, 0, len(fields))
+	for _, field := range fields {
+		fld, err := f.internalFieldByName(field)
+		if err != nil {
+			continue
+		}
+		output = append(output, fld.field())
+	}
+	return output
+}
+
+// ruleError is the error of a rule involving several fields. Its parameter
+// {0} is the labels of the fields joined with a comma. Labels are resolved
+// with the locale the error is translated to.
+type ruleError struct {
+	code   string
+	fr     string
+	en     string
+	fields []*Field
+}
+
+func (e ruleError) Code() string {
+	return e.code
+}
+
+// errorParams returns the labels resolved with the locale of each field.
+func (e ruleError) errorParams() []string {
+	labels := make([]string, len(e.fields))
+	for i, fld := range e.fields {
+		labels[i] = fld.localizedLabel()
+	}
+	return []string{strings.Join(labels, ", ")}
+}
+
+// localizedParams returns the labels resolved with locale.
+func (e ruleError) localizedParams(locale language.Tag) []string {
+	labels := make([]string, len(e.fields))
+	for i, fld := range e.fields {
+		labels[i] = fld.labelT.resolve(locale, fld.label)
+	}
+	return []string{strings.Join(labels, ", ")}
+}
+
+func (e ruleError) Error() string {
+	return formatMessage(localeTranslator(language.English), e.en, e.localizedParams(language.English))
+}
+
+func (e ruleError) Translate(locale string) string {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		tag = defaultLanguage
+	}
+	return translateMessage(locale, e.code, e.localizedParams(tag), func(tag language.Tag) (string, bool) {
+		switch tag {
+		case language.French:
+			return e.fr, true
+		case language.English:
+			return e.en, true
+		default:
+			return "", false
+		}
+	})
+}
+
+func fieldsEqualError(fields []*Field) Error {
+	return ErrorWrap(ruleError{code: FieldsEqualErrorCode, fr: FieldsEqualErrorMessageFr, en: FieldsEqualErrorMessageEn, fields: fields})
+}
+
+func atLeastOneOfError(fields []*Field) Error {
+	return ErrorWrap(ruleError{code: AtLeastOneOfErrorCode, fr: AtLeastOneOfErrorMessageFr, en: AtLeastOneOfErrorMessageEn, fields: fields})
+}
+
+func mutuallyExclusiveError(fields []*Field) Error {
+	return ErrorWrap(ruleError{code: MutuallyExclusiveErrorCode, fr: MutuallyExclusiveErrorMessageFr, en: MutuallyExclusiveErrorMessageEn, fields: fields})
+}
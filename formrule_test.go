@@ -0,0 +1,139 @@
+package aform_test
+
+import (
+	"fmt"
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"testing"
+)
+
+func TestRequiredIf(t *testing.T) {
+	newForm := func() *aform.Form {
+		return aform.Must(aform.New(
+			aform.RequiredIf("Company name", "Employment type", "contractor"),
+			aform.WithChoiceField(aform.Must(aform.DefaultChoiceField("Employment type", aform.WithChoiceOptions([]aform.ChoiceFieldOption{{Value: "employee"}, {Value: "contractor"}})))),
+			aform.WithCharField(aform.Must(aform.DefaultCharField("Company name", aform.IsNotRequired()))),
+		))
+	}
+	tests := []struct {
+		name      string
+		data      map[string][]string
+		wantValid bool
+	}{
+		{
+			name:      "condition not met",
+			data:      map[string][]string{"employment_type": {"employee"}},
+			wantValid: true,
+		},
+		{
+			name:      "condition met and field filled",
+			data:      map[string][]string{"employment_type": {"contractor"}, "company_name": {"Acme"}},
+			wantValid: true,
+		},
+		{
+			name:      "condition met and field empty",
+			data:      map[string][]string{"employment_type": {"contractor"}, "company_name": {" "}},
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			f := newForm()
+			f.BindData(tt.data)
+			a.Equal(tt.wantValid, f.IsValid())
+			if !tt.wantValid {
+				a.Equal(aform.RequiredErrorCode, f.Errors().Get("company_name").Code())
+			}
+		})
+	}
+}
+
+func TestFieldsEqual(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Password", aform.WithWidget(aform.PasswordInput)))),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Confirmation", aform.WithWidget(aform.PasswordInput)))),
+		aform.FieldsEqual("Password", "Confirmation"),
+	))
+	f.BindData(map[string][]string{"password": {"secret"}, "confirmation": {"s3cret"}}, "fr")
+	a.False(f.IsValid())
+	err := f.Errors().Get("confirmation")
+	a.Equal(aform.FieldsEqualErrorCode, err.Code())
+	a.Equal("Ensure this value matches Password", err.Error())
+	a.Equal("Assurez-vous que cette valeur correspond à Password", err.Translate("fr"))
+	a.False(f.Errors().Has("password"))
+	a.False(f.CleanedData().Has("confirmation"))
+}
+
+func TestAtLeastOneOf(t *testing.T) {
+	a := assert.New(t)
+	newForm := func() *aform.Form {
+		return aform.Must(aform.New(
+			aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email", aform.IsNotRequired()))),
+			aform.WithCharField(aform.Must(aform.DefaultCharField("Phone", aform.IsNotRequired()))),
+			aform.AtLeastOneOf("Email", "Phone"),
+		))
+	}
+	f := newForm()
+	f.BindData(map[string][]string{"phone": {"0102030405"}})
+	a.True(f.IsValid())
+	f = newForm()
+	f.BindData(map[string][]string{})
+	a.False(f.IsValid())
+	a.Equal(aform.AtLeastOneOfErrorCode, f.Errors().Get("email").Code())
+	a.Equal("Fill in at least one of these fields: Email, Phone", f.Errors().Get("email").Error())
+}
+
+func TestMutuallyExclusive(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithBooleanField(aform.Must(aform.DefaultBooleanField("Full time", aform.IsNotRequired()))),
+		aform.WithBooleanField(aform.Must(aform.DefaultBooleanField("Part time", aform.IsNotRequired()))),
+		aform.WithBooleanField(aform.Must(aform.DefaultBooleanField("Freelance", aform.IsNotRequired()))),
+		aform.MutuallyExclusive("Full time", "Part time", "Freelance"),
+	))
+	f.BindData(map[string][]string{"full_time": {"on"}, "part_time": {"off"}, "freelance": {"on"}})
+	a.False(f.IsValid())
+	a.Equal(aform.MutuallyExclusiveErrorCode, f.Errors().Get("full_time").Code())
+	a.Equal(aform.MutuallyExclusiveErrorCode, f.Errors().Get("freelance").Code())
+	a.False(f.Errors().Has("part_time"))
+}
+
+func TestFormRule_customizedError(t *testing.T) {
+	a := assert.New(t)
+	confirmation := aform.Must(aform.DefaultCharField("Confirmation"))
+	confirmation.CustomizeError(aform.ErrorWrapWithCode(fmt.Errorf("Passwords don't match"), aform.FieldsEqualErrorCode))
+	f := aform.Must(aform.New(
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Password"))),
+		aform.WithCharField(confirmation),
+		aform.FieldsEqual("Password", "Confirmation"),
+	))
+	f.BindData(map[string][]string{"password": {"secret"}, "confirmation": {"s3cret"}})
+	a.False(f.IsValid())
+	a.Equal("Passwords don't match", f.Errors().Get("confirmation").Error())
+}
+
+func TestFormRule_unknownField(t *testing.T) {
+	a := assert.New(t)
+	_, err := aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("Password"))), aform.FieldsEqual("Password", "Confirmation"))
+	a.EqualError(err, "FieldsEqual: no field with this name Confirmation")
+	_, err = aform.New(aform.AtLeastOneOf())
+	a.EqualError(err, "AtLeastOneOf must have at least one field")
+}
+
+func TestFieldsEqual_labelsTranslated(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithLocales([]language.Tag{language.English, language.French}),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Password", aform.WithLabelT(map[language.Tag]string{language.French: "Mot de passe"})))),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Confirmation"))),
+		aform.FieldsEqual("Password", "Confirmation"),
+	))
+	f.BindData(map[string][]string{"password": {"secret"}, "confirmation": {"s3cret"}}, "fr")
+	a.False(f.IsValid())
+	err := f.Errors().Get("confirmation")
+	a.Equal("Ensure this value matches Password", err.Translate("en"))
+	a.Equal("Assurez-vous que cette valeur correspond à Mot de passe", err.Translate("fr"))
+}
@@ -0,0 +1,736 @@
+package aform
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultFormSetPrefix = "form"
+	// defaultFormSetMaxNum is the default maximum number of forms in a
+	// FormSet. It is also the number of forms accepted above the maximum
+	// before TOTAL_FORMS management data is considered as tampered.
+	defaultFormSetMaxNum    = 1000
+	formSetIndexPlaceholder = "__prefix__"
+)
+
+// Names of the management form fields and of the fields added to each form
+// when deletion or ordering is activated.
+const (
+	TotalFormsFieldName   = "total_forms"
+	InitialFormsFieldName = "initial_forms"
+	MinNumFormsFieldName  = "min_num_forms"
+	MaxNumFormsFieldName  = "max_num_forms"
+	DeleteFieldName       = "delete"
+	OrderFieldName        = "order"
+)
+
+// Error codes of the FormSet validations. Errors with these codes are
+// returned by FormSet.NonFormErrors except OrderErrorCode that is returned
+// by the order field of each form.
+const (
+	ManagementFormErrorCode = "management_form"
+	TooFewFormsErrorCode    = "too_few_forms"
+	TooManyFormsErrorCode   = "too_many_forms"
+	OrderErrorCode          = "order"
+)
+
+// English error messages of the FormSet validations.
+const (
+	ManagementFormErrorMessageEn = "Management form data is missing or has been tampered with"
+	TooFewFormsErrorMessageEn    = "Please submit at least {0, plural, one {# form} other {# forms}}"
+	TooManyFormsErrorMessageEn   = "Please submit at most {0, plural, one {# form} other {# forms}}"
+	OrderErrorMessageEn          = "Enter a whole number"
+)
+
+// French error messages of the FormSet validations.
+const (
+	ManagementFormErrorMessageFr = "Les données du formulaire de gestion sont manquantes ou ont été falsifiées"
+	TooFewFormsErrorMessageFr    = "Veuillez soumettre au moins {0, plural, one {# formulaire} other {# formulaires}}"
+	TooManyFormsErrorMessageFr   = "Veuillez soumettre au plus {0, plural, one {# formulaire} other {# formulaires}}"
+	OrderErrorMessageFr          = "Saisissez un nombre entier"
+)
+
+// German error messages of the FormSet validations.
+const (
+	ManagementFormErrorMessageDe = "Die Daten des Verwaltungsformulars fehlen oder wurden manipuliert"
+	TooFewFormsErrorMessageDe    = "Bitte senden Sie mindestens {0, plural, one {# Formular} other {# Formulare}} ab"
+	TooManyFormsErrorMessageDe   = "Bitte senden Sie höchstens {0, plural, one {# Formular} other {# Formulare}} ab"
+	OrderErrorMessageDe          = "Geben Sie eine ganze Zahl ein"
+)
+
+// Spanish error messages of the FormSet validations.
+const (
+	ManagementFormErrorMessageEs = "Faltan los datos del formulario de gestión o han sido manipulados"
+	TooFewFormsErrorMessageEs    = "Envíe al menos {0, plural, one {# formulario} other {# formularios}}"
+	TooManyFormsErrorMessageEs   = "Envíe como máximo {0, plural, one {# formulario} other {# formularios}}"
+	OrderErrorMessageEs          = "Introduzca un número entero"
+)
+
+// Italian error messages of the FormSet validations.
+const (
+	ManagementFormErrorMessageIt = "I dati del modulo di gestione mancano o sono stati manomessi"
+	TooFewFormsErrorMessageIt    = "Invia almeno {0, plural, one {# modulo} other {# moduli}}"
+	TooManyFormsErrorMessageIt   = "Invia al massimo {0, plural, one {# modulo} other {# moduli}}"
+	OrderErrorMessageIt          = "Inserisci un numero intero"
+)
+
+// Portuguese error messages of the FormSet validations.
+const (
+	ManagementFormErrorMessagePt = "Os dados do formulário de gestão estão em falta ou foram adulterados"
+	TooFewFormsErrorMessagePt    = "Submeta pelo menos {0, plural, one {# formulário} other {# formulários}}"
+	TooManyFormsErrorMessagePt   = "Submeta no máximo {0, plural, one {# formulário} other {# formulários}}"
+	OrderErrorMessagePt          = "Introduza um número inteiro"
+)
+
+// Dutch error messages of the FormSet validations.
+const (
+	ManagementFormErrorMessageNl = "De gegevens van het beheerformulier ontbreken of zijn gewijzigd"
+	TooFewFormsErrorMessageNl    = "Verstuur ten minste {0, plural, one {# formulier} other {# formulieren}}"
+	TooManyFormsErrorMessageNl   = "Verstuur maximaal {0, plural, one {# formulier} other {# formulieren}}"
+	OrderErrorMessageNl          = "Voer een geheel getal in"
+)
+
+// FormFactory defines a function creating the form at position index in a
+// FormSet. Forms at index lower than the number of initial forms are
+// expected to be created with initial values (e.g. existing entries).
+type FormFactory func(index int) (*Form, error)
+
+// FormSet represents a list of identical forms. It manages several copies
+// of a form built by a FormFactory on the same page. All the forms are bound
+// from one request thanks to a prefix added to each form: "<prefix>-<index>".
+// A hidden management form keeps track of the number of forms.
+type FormSet struct {
+	factory      FormFactory
+	prefix       string
+	initialForms int
+	extra        int
+	minNum       int
+	maxNum       int
+	canDelete    bool
+	canOrder     bool
+	management   *Form
+	forms        []*Form
+	bound        bool
+	validated    bool
+	submitted    int
+	buildErr     error
+	resolvers    []LocaleResolver
+	req          *http.Request
+	maxBodyBytes int64
+	maxMemory    int64
+	errors       []Error
+	cleanFunc    func(*FormSet)
+}
+
+// FormSetOption describes a functional option for configuring a FormSet.
+type FormSetOption func(*FormSet) error
+
+// NewFormSet returns a FormSet of forms created with factory. By default,
+// there is no initial form, one extra form, no minimum number of forms and a
+// maximum of 1000 forms. Forms prefix is "form".
+func NewFormSet(factory FormFactory, opts ...FormSetOption) (*FormSet, error) {
+	fs := &FormSet{
+		factory:      factory,
+		prefix:       defaultFormSetPrefix,
+		extra:        1,
+		maxNum:       defaultFormSetMaxNum,
+		maxBodyBytes: defaultMaxBodyBytes,
+		maxMemory:    defaultMaxMemory,
+		cleanFunc:    func(fs *FormSet) {},
+	}
+	for _, opt := range opts {
+		if err := opt(fs); err != nil {
+			return nil, err
+		}
+	}
+	management, err := fs.newManagementForm()
+	if err != nil {
+		return nil, err
+	}
+	fs.management = management
+	if err := fs.buildForms(fs.unboundFormCount()); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+// WithFormSetPrefix returns a FormSetOption that changes the prefix of the
+// management form and of all the forms. Default prefix is "form".
+func WithFormSetPrefix(prefix string) FormSetOption {
+	return func(fs *FormSet) error {
+		if len(prefix) == 0 {
+			return fmt.Errorf("formset prefix can't be empty")
+		}
+		fs.prefix = prefix
+		return nil
+	}
+}
+
+// WithInitialForms returns a FormSetOption that sets the number of initial
+// forms. Initial forms are the forms displaying existing data. They are
+// created first by the FormFactory with index from 0 to n-1.
+func WithInitialForms(n uint) FormSetOption {
+	return func(fs *FormSet) error {
+		fs.initialForms = int(n)
+		return nil
+	}
+}
+
+// WithExtra returns a FormSetOption that sets the number of blank forms
+// displayed after the initial forms. Default is 1.
+func WithExtra(n uint) FormSetOption {
+	return func(fs *FormSet) error {
+		fs.extra = int(n)
+		return nil
+	}
+}
+
+// WithMinNum returns a FormSetOption that sets the minimum number of forms
+// to submit. Deleted forms and extra forms left unchanged are not counted.
+func WithMinNum(n uint) FormSetOption {
+	return func(fs *FormSet) error {
+		fs.minNum = int(n)
+		return nil
+	}
+}
+
+// WithMaxNum returns a FormSetOption that sets the maximum number of forms
+// to submit. Deleted forms and extra forms left unchanged are not counted. It
+// limits as well the number of extra forms displayed. Default is 1000.
+func WithMaxNum(n uint) FormSetOption {
+	return func(fs *FormSet) error {
+		fs.maxNum = int(n)
+		return nil
+	}
+}
+
+// WithFormSetLocaleResolvers returns a FormSetOption that sets the chain of
+// resolvers used by BindRequest to select the locale of the forms. See
+// WithLocaleResolvers.
+func WithFormSetLocaleResolvers(resolvers ...LocaleResolver) FormSetOption {
+	return func(fs *FormSet) error {
+		if len(resolvers) == 0 {
+			return fmt.Errorf("locale resolvers can't be empty")
+		}
+		fs.resolvers = resolvers
+		return nil
+	}
+}
+
+// WithFormSetMaxBodyBytes returns a FormSetOption that limits the size of
+// the request body read by BindRequest. If the body is larger, BindRequest
+// returns an error. n must be greater than 0. Default is 10MB.
+func WithFormSetMaxBodyBytes(n int64) FormSetOption {
+	return func(fs *FormSet) error {
+		if n <= 0 {
+			return fmt.Errorf("max body bytes must be greater than 0. Given: %d", n)
+		}
+		fs.maxBodyBytes = n
+		return nil
+	}
+}
+
+// WithFormSetMaxMemory returns a FormSetOption that sets the maximum size of
+// a multipart body stored in memory by BindRequest. See WithMaxMemory.
+func WithFormSetMaxMemory(n int64) FormSetOption {
+	return func(fs *FormSet) error {
+		if n <= 0 {
+			return fmt.Errorf("max memory must be greater than 0. Given: %d", n)
+		}
+		fs.maxMemory = n
+		return nil
+	}
+}
+
+// WithCanDelete returns a FormSetOption that adds a not required
+// BooleanField named "delete" to each form. Forms marked for deletion are
+// not validated and are listed by FormSet.DeletedForms.
+func WithCanDelete() FormSetOption {
+	return func(fs *FormSet) error {
+		fs.canDelete = true
+		return nil
+	}
+}
+
+// WithCanOrder returns a FormSetOption that adds a not required CharField
+// named "order" to each form. It must contain a whole number. Valid forms
+// sorted with this field are returned by FormSet.OrderedForms.
+func WithCanOrder() FormSetOption {
+	return func(fs *FormSet) error {
+		fs.canOrder = true
+		return nil
+	}
+}
+
+func (fs *FormSet) newManagementForm() (*Form, error) {
+	counts := []struct {
+		name     string
+		value    int
+		required bool
+	}{
+		{TotalFormsFieldName, fs.unboundFormCount(), true},
+		{InitialFormsFieldName, fs.initialForms, true},
+		{MinNumFormsFieldName, fs.minNum, false},
+		{MaxNumFormsFieldName, fs.maxNum, false},
+	}
+	opts := []FormOption{WithPrefix(fs.prefix)}
+	for _, count := range counts {
+		fldOpts := []FieldOption{WithWidget(HiddenInput)}
+		if !count.required {
+			fldOpts = append(fldOpts, IsNotRequired())
+		}
+		fld, err := NewCharField(count.name, strconv.Itoa(count.value), "", 0, 0, fldOpts...)
+		if err != nil {
+			return nil, err
+		}
+		fld.SetValidateFunc(func(current ValidationFunc) ValidationFunc {
+			return func(value string, required bool) []Error {
+				if errs := current(value, required); len(errs) > 0 {
+					return errs
+				}
+				if n, err := strconv.Atoi(value); err != nil || n < 0 {
+					return []Error{managementFormError}
+				}
+				return nil
+			}
+		})
+		opts = append(opts, WithCharField(fld))
+	}
+	return New(opts...)
+}
+
+// unboundFormCount returns the number of forms displayed before binding.
+func (fs *FormSet) unboundFormCount() int {
+	total := fs.initialForms + fs.extra
+	if fs.initialForms > fs.maxNum {
+		return fs.initialForms
+	}
+	if total > fs.maxNum {
+		return fs.maxNum
+	}
+	return total
+}
+
+func (fs *FormSet) absoluteMaxNum() int {
+	return fs.maxNum + defaultFormSetMaxNum
+}
+
+func (fs *FormSet) buildForms(count int) error {
+	forms := make([]*Form, count)
+	for i := 0; i < count; i++ {
+		f, err := fs.buildForm(i, strconv.Itoa(i))
+		if err != nil {
+			return err
+		}
+		forms[i] = f
+	}
+	fs.forms = forms
+	return nil
+}
+
+func (fs *FormSet) buildForm(index int, prefixIndex string) (*Form, error) {
+	f, err := fs.factory(index)
+	if err != nil {
+		return nil, err
+	}
+	if err := WithPrefix(prefixedName(fs.prefix, prefixIndex))(f); err != nil {
+		return nil, err
+	}
+	for _, fld := range fs.management.fields {
+		f.managementKeys = append(f.managementKeys, prefixedNameForField(fld))
+	}
+	if fs.canOrder {
+		initial := ""
+		if index < fs.initialForms {
+			initial = strconv.Itoa(index + 1)
+		}
+		fld, err := NewCharField(OrderFieldName, initial, "", 0, 0, WithLabel("Order"), IsNotRequired())
+		if err != nil {
+			return nil, err
+		}
+		fld.SetValidateFunc(orderFieldValidationFunc)
+		if err := f.addField(fld); err != nil {
+			return nil, err
+		}
+	}
+	if fs.canDelete {
+		fld, err := DefaultBooleanField(DeleteFieldName, WithLabel("Delete"), IsNotRequired())
+		if err != nil {
+			return nil, err
+		}
+		if err := f.addField(fld); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func orderFieldValidationFunc(_ ValidationFunc) ValidationFunc {
+	return func(value string, required bool) []Error {
+		if _, err := strconv.Atoi(value); err != nil {
+			return []Error{orderError}
+		}
+		return nil
+	}
+}
+
+// BindRequest binds req form data to the management form and to all the
+// forms. The number of forms bound is read from the management form. Error
+// messages are localized according to the locale resolved with the resolvers
+// set with WithFormSetLocaleResolvers, by default the Accept-Language header.
+// Request is parsed with the limits set with WithFormSetMaxBodyBytes and
+// WithFormSetMaxMemory. Each form is bound with req like with
+// Form.BindRequest, so forms created with WithCSRF check the token of req and
+// forms are validated with the context of req. An error is returned if the
+// request can't be parsed or if the FormFactory fails to create a form. See
+// Form.BindRequest for details.
+func (fs *FormSet) BindRequest(req *http.Request) error {
+	if fs.bound {
+		return nil
+	}
+	if err := parseRequest(req, fs.maxBodyBytes, fs.maxMemory); err != nil {
+		return err
+	}
+	fs.bindData(req, req.Form, resolveLocales(req, fs.resolvers)...)
+	return fs.buildErr
+}
+
+// BindData binds data to the management form and to all the forms. After a
+// first binding, following bindings are ignored. The number of forms bound is
+// read from the management form. It's capped to the maximum number of forms
+// plus 1000, a formset submitting more forms is not valid. If the FormFactory
+// returns an error, no form is bound and the error is returned by
+// NonFormErrors. See Form.BindData for details.
+func (fs *FormSet) BindData(data map[string][]string, langs ...string) {
+	fs.bindData(nil, data, langs...)
+}
+
+// bindData binds data to the management form and to all the forms. If req is
+// not nil, forms are bound with Form.bindRequestData.
+func (fs *FormSet) bindData(req *http.Request, data map[string][]string, langs ...string) {
+	if fs.bound {
+		return
+	}
+	fs.bound = true
+	fs.management.BindData(data, langs...)
+	count := 0
+	if fs.management.IsValid() {
+		fs.submitted, _ = strconv.Atoi(fs.management.CleanedData().Get(TotalFormsFieldName))
+		count = fs.submitted
+		if count > fs.absoluteMaxNum() {
+			count = fs.absoluteMaxNum()
+		}
+	}
+	if err := fs.buildForms(count); err != nil {
+		fs.buildErr = err
+		fs.forms = []*Form{}
+		return
+	}
+	for _, f := range fs.forms {
+		if req != nil {
+			f.bindRequestData(req, langs...)
+			continue
+		}
+		f.BindData(data, langs...)
+	}
+}
+
+// IsBound returns true if the formset is already bound to data
+// with BindRequest or BindData.
+func (fs *FormSet) IsBound() bool {
+	return fs.bound
+}
+
+// Forms returns the list of forms of the formset. Forms created as initial
+// forms come first.
+func (fs *FormSet) Forms() []*Form {
+	return fs.forms
+}
+
+// ManagementForm returns the hidden form keeping track of the number of
+// forms. It is rendered by AsDiv. When forms are rendered one by one, the
+// management form must be rendered too.
+func (fs *FormSet) ManagementForm() *Form {
+	return fs.management
+}
+
+// EmptyForm returns a new form with the index replaced by the placeholder
+// "__prefix__". It is useful to add forms dynamically with JavaScript.
+func (fs *FormSet) EmptyForm() (*Form, error) {
+	f, err := fs.buildForm(fs.TotalFormCount(), formSetIndexPlaceholder)
+	if err != nil {
+		return nil, err
+	}
+	fs.setFormRequest(f)
+	return f, nil
+}
+
+// SetRequest sets the request the formset is rendered for. It is required to
+// render an unbound formset of forms created with WithCSRF. The locale of the
+// forms of an unbound formset, including EmptyForm, is selected with the
+// resolvers set with WithFormSetLocaleResolvers. See Form.SetRequest.
+func (fs *FormSet) SetRequest(req *http.Request) {
+	fs.req = req
+	fs.setFormRequest(fs.management)
+	for _, f := range fs.forms {
+		fs.setFormRequest(f)
+	}
+}
+
+// setFormRequest sets the request of the formset to f. If the formset is not
+// bound, the locale of f is selected with the resolvers of the formset.
+func (fs *FormSet) setFormRequest(f *Form) {
+	if fs.req == nil {
+		return
+	}
+	f.req = fs.req
+	if !fs.bound {
+		f.SelectLocale(resolveLocales(fs.req, fs.resolvers)...)
+	}
+}
+
+// TotalFormCount returns the number of forms in the formset.
+func (fs *FormSet) TotalFormCount() int {
+	return len(fs.forms)
+}
+
+// InitialFormCount returns the number of initial forms. Once bound, it is the
+// number sent back in the management form.
+func (fs *FormSet) InitialFormCount() int {
+	if fs.bound && fs.management.IsValid() {
+		n, _ := strconv.Atoi(fs.management.CleanedData().Get(InitialFormsFieldName))
+		return n
+	}
+	return fs.initialForms
+}
+
+// AsDiv renders the management form followed by all the forms as a list of
+// <div> tags.
+func (fs *FormSet) AsDiv() template.HTML {
+	var b strings.Builder
+	for _, fld := range fs.management.Fields() {
+		b.WriteString("\n")
+		b.WriteString(string(fld.Widget()))
+	}
+	for _, f := range fs.forms {
+		b.WriteString(string(f.AsDiv()))
+	}
+	return template.HTML(b.String())
+}
+
+// IsValid returns true if the management form is valid, if all the forms
+// not deleted are valid and if the formset clean function doesn't add any
+// error. Extra forms left unchanged are not validated.
+func (fs *FormSet) IsValid() bool {
+	if !fs.IsBound() {
+		return false
+	}
+	fs.doValidationIfNeeded()
+	if len(fs.errors) > 0 {
+		return false
+	}
+	for i, f := range fs.forms {
+		if fs.skipValidation(i) {
+			continue
+		}
+		if !f.IsValid() {
+			return false
+		}
+	}
+	return true
+}
+
+// CleanedData returns the cleaned data of each form in the order of
+// Forms. Deleted forms, unchanged extra forms and invalid forms have an empty
+// CleanedData.
+func (fs *FormSet) CleanedData() []CleanedData {
+	output := make([]CleanedData, len(fs.forms))
+	if !fs.IsBound() {
+		for i := range output {
+			output[i] = CleanedData{}
+		}
+		return output
+	}
+	fs.doValidationIfNeeded()
+	for i, f := range fs.forms {
+		if fs.skipValidation(i) || !f.IsValid() {
+			output[i] = CleanedData{}
+			continue
+		}
+		output[i] = f.CleanedData()
+	}
+	return output
+}
+
+// Errors returns the errors of each form in the order of Forms. Deleted forms
+// and unchanged extra forms have no error. Formset level errors are returned
+// by NonFormErrors.
+func (fs *FormSet) Errors() []FormErrors {
+	output := make([]FormErrors, len(fs.forms))
+	for i, f := range fs.forms {
+		if !fs.IsBound() || fs.skipValidation(i) {
+			output[i] = FormErrors{}
+			continue
+		}
+		output[i] = f.Errors()
+	}
+	return output
+}
+
+// NonFormErrors returns the errors not attached to a specific form. They are
+// errors from the management form, from the minimum and maximum number of
+// forms and errors added with AddError.
+func (fs *FormSet) NonFormErrors() []Error {
+	if !fs.IsBound() {
+		return []Error{}
+	}
+	fs.doValidationIfNeeded()
+	output := make([]Error, len(fs.errors))
+	copy(output, fs.errors)
+	return output
+}
+
+// DeletedForms returns the forms marked for deletion. It's always empty if
+// the formset has not been created with WithCanDelete.
+func (fs *FormSet) DeletedForms() []*Form {
+	var output []*Form
+	for i, f := range fs.forms {
+		if fs.isDeleted(i) {
+			output = append(output, f)
+		}
+	}
+	return output
+}
+
+// OrderedForms returns the valid forms, except deleted forms and unchanged
+// extra forms, sorted by the value of their order field. Forms without order
+// come last. It's always empty if the formset has not been created with
+// WithCanOrder.
+func (fs *FormSet) OrderedForms() []*Form {
+	if !fs.canOrder || !fs.IsBound() {
+		return nil
+	}
+	fs.doValidationIfNeeded()
+	type orderedForm struct {
+		form     *Form
+		order    int
+		hasOrder bool
+	}
+	var list []orderedForm
+	for i, f := range fs.forms {
+		if fs.skipValidation(i) || !f.IsValid() {
+			continue
+		}
+		order, err := strconv.Atoi(f.CleanedData().Get(OrderFieldName))
+		list = append(list, orderedForm{form: f, order: order, hasOrder: err == nil})
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].hasOrder != list[j].hasOrder {
+			return list[i].hasOrder
+		}
+		return list[i].order < list[j].order
+	})
+	output := make([]*Form, len(list))
+	for i, of := range list {
+		output[i] = of.form
+	}
+	return output
+}
+
+// SetCleanFunc sets a clean function to do validation at the formset level.
+// It's called after all the forms have been validated.
+func (fs *FormSet) SetCleanFunc(clean func(*FormSet)) {
+	fs.cleanFunc = clean
+}
+
+// AddError adds an error at the formset level. It can be used in the
+// formset clean function set with SetCleanFunc. Errors added are returned by
+// NonFormErrors.
+func (fs *FormSet) AddError(err error) error {
+	if !fs.validated {
+		return fmt.Errorf("you can't add an error to a formset not already validated. " +
+			"A formset is validated when one of the following method is called: " +
+			"CleanedData(), IsValid() or NonFormErrors()")
+	}
+	fs.errors = append(fs.errors, errorWrapIfNotAsError(err))
+	return nil
+}
+
+func (fs *FormSet) doValidationIfNeeded() {
+	if fs.validated {
+		return
+	}
+	fs.validated = true
+	fs.errors = []Error{}
+	if !fs.management.IsValid() {
+		fs.errors = append(fs.errors, managementFormError)
+		fs.cleanFunc(fs)
+		return
+	}
+	if fs.buildErr != nil {
+		fs.errors = append(fs.errors, errorWrapIfNotAsError(fs.buildErr))
+		fs.cleanFunc(fs)
+		return
+	}
+	submitted := 0
+	for i, f := range fs.forms {
+		if fs.skipValidation(i) {
+			continue
+		}
+		f.IsValid()
+		submitted++
+	}
+	if submitted > fs.maxNum || fs.submitted > fs.absoluteMaxNum() {
+		fs.errors = append(fs.errors, tooManyFormsError(fs.maxNum))
+	}
+	if submitted < fs.minNum {
+		fs.errors = append(fs.errors, tooFewFormsError(fs.minNum))
+	}
+	fs.cleanFunc(fs)
+}
+
+// skipValidation returns true if the form at index i must not be validated
+// because it is marked for deletion or because it is an extra form left
+// unchanged.
+func (fs *FormSet) skipValidation(i int) bool {
+	if fs.isDeleted(i) {
+		return true
+	}
+	return i >= fs.InitialFormCount() && !fs.forms[i].HasChanged()
+}
+
+func (fs *FormSet) isDeleted(i int) bool {
+	if !fs.canDelete || !fs.bound {
+		return false
+	}
+	values := fs.forms[i].boundData[DeleteFieldName]
+	return len(values) > 0 && valueToBool(values[0])
+}
+
+var (
+	managementFormError = ErrorWrap(simpleError{code: ManagementFormErrorCode, fr: ManagementFormErrorMessageFr, en: ManagementFormErrorMessageEn})
+	orderError          = ErrorWrap(simpleError{code: OrderErrorCode, fr: OrderErrorMessageFr, en: OrderErrorMessageEn})
+)
+
+func tooFewFormsError(min int) Error {
+	return ErrorWrap(simpleError{
+		code:   TooFewFormsErrorCode,
+		fr:     TooFewFormsErrorMessageFr,
+		en:     TooFewFormsErrorMessageEn,
+		params: []string{strconv.Itoa(min)},
+	})
+}
+
+func tooManyFormsError(max int) Error {
+	return ErrorWrap(simpleError{
+		code:   TooManyFormsErrorCode,
+		fr:     TooManyFormsErrorMessageFr,
+		en:     TooManyFormsErrorMessageEn,
+		params: []string{strconv.Itoa(max)},
+	})
+}
@@ -0,0 +1,339 @@
+package aform_test
+
+import (
+	"fmt"
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewFormSet_unbound(t *testing.T) {
+	a := assert.New(t)
+	fs := aform.Must(aform.NewFormSet(func(index int) (*aform.Form, error) {
+		initials := []string{"Acme"}
+		initial := ""
+		if index < len(initials) {
+			initial = initials[index]
+		}
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", initial, "", 0, 64))))
+	}, aform.WithInitialForms(1), aform.WithExtra(1)))
+	a.Equal(2, fs.TotalFormCount())
+	a.Equal(1, fs.InitialFormCount())
+	a.False(fs.IsBound())
+	a.False(fs.IsValid())
+	expected := `
+<input type="hidden" name="form-total_forms" value="2" id="id_form-total_forms" required>
+<input type="hidden" name="form-initial_forms" value="1" id="id_form-initial_forms" required>
+<input type="hidden" name="form-min_num_forms" value="0" id="id_form-min_num_forms">
+<input type="hidden" name="form-max_num_forms" value="1000" id="id_form-max_num_forms">
+<div><label for="id_form-0-company">Company</label><input type="text" name="form-0-company" value="Acme" id="id_form-0-company" maxlength="64" required></div>
+<div><label for="id_form-1-company">Company</label><input type="text" name="form-1-company" id="id_form-1-company" maxlength="64" required></div>`
+	a.Equal(expected, string(fs.AsDiv()))
+}
+
+func TestNewFormSet_maxNumLimitsExtraForms(t *testing.T) {
+	a := assert.New(t)
+	fs := aform.Must(aform.NewFormSet(func(int) (*aform.Form, error) {
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", "", "", 0, 64))))
+	}, aform.WithExtra(5), aform.WithMaxNum(2)))
+	a.Equal(2, fs.TotalFormCount())
+}
+
+func TestNewFormSet_emptyPrefix(t *testing.T) {
+	a := assert.New(t)
+	_, err := aform.NewFormSet(func(int) (*aform.Form, error) {
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", "", "", 0, 64))))
+	}, aform.WithFormSetPrefix(""))
+	a.EqualError(err, "formset prefix can't be empty")
+}
+
+func TestFormSet_BindData_valid(t *testing.T) {
+	a := assert.New(t)
+	fs := aform.Must(aform.NewFormSet(func(index int) (*aform.Form, error) {
+		initials := []string{"Acme"}
+		initial := ""
+		if index < len(initials) {
+			initial = initials[index]
+		}
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", initial, "", 0, 64))))
+	}, aform.WithInitialForms(1), aform.WithExtra(2)))
+	fs.BindData(map[string][]string{
+		"form-total_forms":   {"3"},
+		"form-initial_forms": {"1"},
+		"form-0-company":     {"Acme Corp"},
+		"form-1-company":     {"Globex"},
+		"form-2-company":     {""},
+	})
+	a.True(fs.IsValid())
+	a.Empty(fs.NonFormErrors())
+	cleanedData := fs.CleanedData()
+	a.Len(cleanedData, 3)
+	a.Equal("Acme Corp", cleanedData[0].Get("company"))
+	a.Equal("Globex", cleanedData[1].Get("company"))
+	a.Empty(cleanedData[2])
+}
+
+func TestFormSet_BindData_invalidForm(t *testing.T) {
+	a := assert.New(t)
+	fs := aform.Must(aform.NewFormSet(func(index int) (*aform.Form, error) {
+		initials := []string{"Acme"}
+		initial := ""
+		if index < len(initials) {
+			initial = initials[index]
+		}
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", initial, "", 0, 64))))
+	}, aform.WithInitialForms(1)))
+	fs.BindData(map[string][]string{
+		"form-total_forms":   {"2"},
+		"form-initial_forms": {"1"},
+		"form-1-company":     {"Globex"},
+	})
+	a.False(fs.IsValid())
+	errs := fs.Errors()
+	a.Equal(aform.RequiredErrorCode, errs[0].Get("company").Code())
+	a.Empty(errs[1])
+}
+
+func TestFormSet_BindData_missingManagementForm(t *testing.T) {
+	a := assert.New(t)
+	fs := aform.Must(aform.NewFormSet(func(int) (*aform.Form, error) {
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", "", "", 0, 64))))
+	}))
+	fs.BindData(map[string][]string{"form-0-company": {"Acme"}})
+	a.False(fs.IsValid())
+	a.Equal(0, fs.TotalFormCount())
+	errs := fs.NonFormErrors()
+	a.Len(errs, 1)
+	a.Equal(aform.ManagementFormErrorCode, errs[0].Code())
+	a.Equal(aform.ManagementFormErrorMessageFr, errs[0].Translate("fr"))
+	a.Equal(aform.ManagementFormErrorMessageNl, errs[0].Translate("nl"))
+}
+
+func TestFormSet_BindData_minAndMaxNum(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string][]string
+		wantCode string
+		wantEn   string
+		wantDe   string
+	}{
+		{
+			name: "too few",
+			data: map[string][]string{
+				"form-total_forms":   {"3"},
+				"form-initial_forms": {"0"},
+				"form-0-company":     {"Acme"},
+			},
+			wantCode: aform.TooFewFormsErrorCode,
+			wantEn:   "Please submit at least 2 forms",
+			wantDe:   "Bitte senden Sie mindestens 2 Formulare ab",
+		},
+		{
+			name: "too many",
+			data: map[string][]string{
+				"form-total_forms":   {"4"},
+				"form-initial_forms": {"0"},
+				"form-0-company":     {"Acme"},
+				"form-1-company":     {"Globex"},
+				"form-2-company":     {"Initech"},
+				"form-3-company":     {"Hooli"},
+			},
+			wantCode: aform.TooManyFormsErrorCode,
+			wantEn:   "Please submit at most 3 forms",
+			wantDe:   "Bitte senden Sie höchstens 3 Formulare ab",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			fs := aform.Must(aform.NewFormSet(func(int) (*aform.Form, error) {
+				return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", "", "", 0, 64))))
+			}, aform.WithMinNum(2), aform.WithMaxNum(3)))
+			fs.BindData(tt.data)
+			a.False(fs.IsValid())
+			errs := fs.NonFormErrors()
+			a.Len(errs, 1)
+			a.Equal(tt.wantCode, errs[0].Code())
+			a.Equal(tt.wantEn, errs[0].Translate("en"))
+			a.Equal(tt.wantDe, errs[0].Translate("de"))
+		})
+	}
+}
+
+func TestFormSet_WithCanDelete(t *testing.T) {
+	a := assert.New(t)
+	fs := aform.Must(aform.NewFormSet(func(index int) (*aform.Form, error) {
+		initials := []string{"Acme", "Globex"}
+		initial := ""
+		if index < len(initials) {
+			initial = initials[index]
+		}
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", initial, "", 0, 64))))
+	}, aform.WithInitialForms(2), aform.WithExtra(0), aform.WithCanDelete()))
+	fs.BindData(map[string][]string{
+		"form-total_forms":   {"2"},
+		"form-initial_forms": {"2"},
+		"form-0-company":     {""},
+		"form-0-delete":      {"on"},
+		"form-1-company":     {"Globex"},
+	})
+	a.True(fs.IsValid())
+	a.Equal([]*aform.Form{fs.Forms()[0]}, fs.DeletedForms())
+	cleanedData := fs.CleanedData()
+	a.Empty(cleanedData[0])
+	a.Equal("Globex", cleanedData[1].Get("company"))
+	a.Equal("off", cleanedData[1].Get("delete"))
+}
+
+func TestFormSet_WithCanOrder(t *testing.T) {
+	a := assert.New(t)
+	fs := aform.Must(aform.NewFormSet(func(int) (*aform.Form, error) {
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", "", "", 0, 64))))
+	}, aform.WithExtra(3), aform.WithCanOrder()))
+	fs.BindData(map[string][]string{
+		"form-total_forms":   {"3"},
+		"form-initial_forms": {"0"},
+		"form-0-company":     {"Acme"},
+		"form-0-order":       {"2"},
+		"form-1-company":     {"Globex"},
+		"form-2-company":     {"Initech"},
+		"form-2-order":       {"1"},
+	})
+	a.True(fs.IsValid())
+	ordered := fs.OrderedForms()
+	a.Len(ordered, 3)
+	a.Equal("Initech", ordered[0].CleanedData().Get("company"))
+	a.Equal("Acme", ordered[1].CleanedData().Get("company"))
+	a.Equal("Globex", ordered[2].CleanedData().Get("company"))
+}
+
+func TestFormSet_WithCanOrder_invalidOrder(t *testing.T) {
+	a := assert.New(t)
+	fs := aform.Must(aform.NewFormSet(func(int) (*aform.Form, error) {
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", "", "", 0, 64))))
+	}, aform.WithCanOrder()))
+	fs.BindData(map[string][]string{
+		"form-total_forms":   {"1"},
+		"form-initial_forms": {"0"},
+		"form-0-company":     {"Acme"},
+		"form-0-order":       {"first"},
+	})
+	a.False(fs.IsValid())
+	a.Equal(aform.OrderErrorCode, fs.Errors()[0].Get("order").Code())
+}
+
+func TestFormSet_SetCleanFunc(t *testing.T) {
+	a := assert.New(t)
+	fs := aform.Must(aform.NewFormSet(func(int) (*aform.Form, error) {
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", "", "", 0, 64))))
+	}, aform.WithExtra(2)))
+	fs.SetCleanFunc(func(fs *aform.FormSet) {
+		seen := map[string]bool{}
+		for _, data := range fs.CleanedData() {
+			if company := data.Get("company"); seen[company] {
+				_ = fs.AddError(fmt.Errorf("duplicated company %s", company))
+			} else if len(company) > 0 {
+				seen[company] = true
+			}
+		}
+	})
+	fs.BindData(map[string][]string{
+		"form-total_forms":   {"2"},
+		"form-initial_forms": {"0"},
+		"form-0-company":     {"Acme"},
+		"form-1-company":     {"Acme"},
+	})
+	a.False(fs.IsValid())
+	a.Equal("duplicated company Acme", fs.NonFormErrors()[0].Error())
+}
+
+func TestFormSet_AddError_notValidated(t *testing.T) {
+	a := assert.New(t)
+	fs := aform.Must(aform.NewFormSet(func(int) (*aform.Form, error) {
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", "", "", 0, 64))))
+	}))
+	a.Error(fs.AddError(fmt.Errorf("too early")))
+}
+
+func TestFormSet_EmptyForm(t *testing.T) {
+	a := assert.New(t)
+	fs := aform.Must(aform.NewFormSet(func(int) (*aform.Form, error) {
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", "", "", 0, 64))))
+	}))
+	f, err := fs.EmptyForm()
+	a.NoError(err)
+	a.Equal(`
+<div><label for="id_form-__prefix__-company">Company</label><input type="text" name="form-__prefix__-company" id="id_form-__prefix__-company" maxlength="64" required></div>`, string(f.AsDiv()))
+}
+
+func TestFormSet_BindData_tooManySubmittedForms(t *testing.T) {
+	a := assert.New(t)
+	fs := aform.Must(aform.NewFormSet(func(int) (*aform.Form, error) {
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", "", "", 0, 64))))
+	}, aform.WithMaxNum(3)))
+	fs.BindData(map[string][]string{
+		"form-total_forms":   {"100000"},
+		"form-initial_forms": {"0"},
+	})
+	a.Equal(1003, fs.TotalFormCount())
+	a.False(fs.IsValid())
+	errs := fs.NonFormErrors()
+	a.Len(errs, 1)
+	a.Equal(aform.TooManyFormsErrorCode, errs[0].Code())
+}
+
+func TestFormSet_BindRequest_factoryError(t *testing.T) {
+	a := assert.New(t)
+	calls := 0
+	factory := func(index int) (*aform.Form, error) {
+		calls++
+		if calls > 1 {
+			return nil, fmt.Errorf("factory failed")
+		}
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", "", "", 0, 64))))
+	}
+	fs := aform.Must(aform.NewFormSet(factory))
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("form-total_forms=2&form-initial_forms=0"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	a.NotPanics(func() {
+		a.EqualError(fs.BindRequest(req), "factory failed")
+	})
+	a.False(fs.IsValid())
+	a.Empty(fs.Forms())
+	errs := fs.NonFormErrors()
+	a.Len(errs, 1)
+	a.Equal("factory failed", errs[0].Error())
+}
+
+func TestFormSet_BindRequest_bodyTooLarge(t *testing.T) {
+	a := assert.New(t)
+	fs := aform.Must(aform.NewFormSet(func(int) (*aform.Form, error) {
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", "", "", 0, 64))))
+	}, aform.WithFormSetMaxBodyBytes(8)))
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("form-total_forms=1&form-initial_forms=0"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	a.Error(fs.BindRequest(req))
+	a.False(fs.IsBound())
+}
+
+func TestWithFormSetMaxMemory_invalid(t *testing.T) {
+	a := assert.New(t)
+	_, err := aform.NewFormSet(func(int) (*aform.Form, error) {
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", "", "", 0, 64))))
+	}, aform.WithFormSetMaxMemory(0))
+	a.EqualError(err, "max memory must be greater than 0. Given: 0")
+}
+
+func TestWithFormSetMaxBodyBytes_invalid(t *testing.T) {
+	a := assert.New(t)
+	factory := func(int) (*aform.Form, error) {
+		return aform.New(aform.WithCharField(aform.Must(aform.NewCharField("Company", "", "", 0, 64))))
+	}
+	_, err := aform.NewFormSet(factory, aform.WithFormSetMaxBodyBytes(0))
+	a.EqualError(err, "max body bytes must be greater than 0. Given: 0")
+	_, err = aform.NewFormSet(factory, aform.WithFormSetMaxBodyBytes(-1))
+	a.EqualError(err, "max body bytes must be greater than 0. Given: -1")
+}
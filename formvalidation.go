@@ -27,7 +27,8 @@ func (f *Form) CleanedData() CleanedData {
 
 // Errors returns errors happened during validation of form inputs. It does Form
 // validation if it is not already done. If a field input is valid it doesn't
-// appear in FormErrors.
+// appear in FormErrors. Errors not attached to a field are under the key
+// NonFieldErrorsKey.
 func (f *Form) Errors() FormErrors {
 	if !f.IsBound() {
 		return map[string][]Error{}
@@ -44,7 +45,16 @@ func (f *Form) Errors() FormErrors {
 	return output
 }
 
+// NonFieldErrors returns errors not attached to a field. They are added with
+// AddError and an empty field name or by form level validations like
+// WithStrictBinding. It does Form validation if it is not already done.
+func (f *Form) NonFieldErrors() []Error {
+	return f.Errors()[NonFieldErrorsKey]
+}
+
 // SetCleanFunc sets a clean function to do validation at the form level.
+// It's called after the rules added with RequiredIf, FieldsEqual,
+// AtLeastOneOf and MutuallyExclusive.
 func (f *Form) SetCleanFunc(clean func(*Form)) {
 	f.cleanFunc = clean
 }
@@ -52,6 +62,8 @@ func (f *Form) SetCleanFunc(clean func(*Form)) {
 // AddError adds an error to the field named field. An error can be added after
 // the form has been validated. AddError can be used in the form clean function
 // set with SetCleanFunc to perform Form level validation.
+// If field is the empty string or NonFieldErrorsKey, the error is not
+// attached to a field. It is returned by NonFieldErrors.
 // If err implements ErrorCoderTranslator, error message will be translated
 // according to the language automatically identified by BindRequest or
 // according to the language given to BindData.
@@ -61,6 +73,10 @@ func (f *Form) AddError(field string, fieldErr error) error {
 			"A form is validated when one of the following method is called: " +
 			"CleanedData(), IsValid() or Errors()")
 	}
+	if field == "" || field == NonFieldErrorsKey {
+		f.errors[NonFieldErrorsKey] = append(f.errors[NonFieldErrorsKey], errorWrapIfNotAsError(fieldErr))
+		return nil
+	}
 	fld, err := f.internalFieldByName(field)
 	if err != nil {
 		return err
@@ -70,6 +86,7 @@ func (f *Form) AddError(field string, fieldErr error) error {
 	fld.addError(wrappedFieldErr)
 	f.errors[nName] = append(f.errors[nName], wrappedFieldErr)
 	delete(f.cleanedData, nName)
+	f.setAutofocus()
 	return nil
 }
 
@@ -78,10 +95,23 @@ func (f *Form) doValidationIfNeeded() {
 		return
 	}
 	f.validated = true
+	defer f.setAutofocus()
 	cleanedData := map[string][]string{}
 	errors := map[string][]Error{}
+	ctx := f.context()
 	for _, fld := range f.fields {
+		if err := ctx.Err(); err != nil {
+			f.stopValidation(cleanedData, errors, err)
+			return
+		}
+		fld.field().ctx = ctx
+		fld.field().warnings = nil
 		nName := normalizedNameForField(fld)
+		if f.tampered[nName] {
+			errors[nName] = customizeErrors([]Error{signatureError}, fld.field().customErrors)
+			fld.field().errors = errors[nName]
+			continue
+		}
 		values, ok := f.boundData[nName]
 		if !ok {
 			values = []string{}
@@ -94,16 +124,45 @@ func (f *Form) doValidationIfNeeded() {
 			cleanedData[nName] = cleanValues
 		}
 	}
+	nonFieldErrs := f.bindErrors
+	if !f.restored {
+		nonFieldErrs = append(append(f.csrfErrors(), f.antiSpamErrors()...), nonFieldErrs...)
+	}
+	if len(nonFieldErrs) > 0 {
+		errors[NonFieldErrorsKey] = nonFieldErrs
+	}
+	if err := ctx.Err(); err != nil {
+		f.stopValidation(cleanedData, errors, err)
+		return
+	}
 	f.cleanedData = cleanedData
 	f.errors = errors
+	f.applyRules()
 	f.cleanFunc(f)
+	if err := ctx.Err(); err != nil {
+		f.errors[NonFieldErrorsKey] = append(f.errors[NonFieldErrorsKey], newCanceledError(err))
+		return
+	}
+	if len(f.errors) == 0 && !f.restored {
+		if errs := f.oneTimeTokenErrors(); len(errs) > 0 {
+			f.errors[NonFieldErrorsKey] = errs
+		}
+	}
+}
+
+// stopValidation ends a validation interrupted by the context error err.
+// Rules, the clean function and the one-time token are skipped.
+func (f *Form) stopValidation(cleanedData map[string][]string, errors map[string][]Error, err error) {
+	errors[NonFieldErrorsKey] = append(errors[NonFieldErrorsKey], newCanceledError(err))
+	f.cleanedData = cleanedData
+	f.errors = errors
 }
 
 func disguiseFieldForValidation(fld fieldInterface) multipleValueValidationStateProvider {
 	switch fld.Type() {
 	case BooleanFieldType, CharFieldType, EmailFieldType, URLFieldType, ChoiceFieldType:
 		return singleValueDisguisedInMultipleValueValidationStateProvider{p: fld.(singleValueValidationStateProvider)}
-	case MultipleChoiceFieldType:
+	case MultipleChoiceFieldType, CaptchaFieldType:
 		return fld.(multipleValueValidationStateProvider)
 	default:
 		panic(fmt.Sprintf("unknown field type %s", fld.Type()))
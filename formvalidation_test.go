@@ -445,3 +445,17 @@ func TestForm_SetCleanFunc_withAddError(t *testing.T) {
 	a.Len(f.Errors(), 1)
 	a.Equal("she's a girl", f.Errors().Get("title").Error())
 }
+
+func TestForm_AddError_nonFieldError(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("First")))))
+	f.SetCleanFunc(func(f *aform.Form) {
+		_ = f.AddError("", fmt.Errorf("global error"))
+		_ = f.AddError(aform.NonFieldErrorsKey, fmt.Errorf("another global error"))
+	})
+	f.BindData(map[string][]string{"first": {"good"}})
+	a.False(f.IsValid())
+	a.Len(f.NonFieldErrors(), 2)
+	a.Equal("global error", f.Errors().Get(aform.NonFieldErrorsKey).Error())
+	a.Equal("good", f.CleanedData().Get("first"))
+}
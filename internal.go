@@ -1,6 +1,7 @@
 package aform
 
 import (
+	"context"
 	"golang.org/x/text/language"
 	"html/template"
 	"net/http"
@@ -11,16 +12,27 @@ var _ formInterface = (*Form)(nil)
 
 type formInterface interface {
 	AsDiv() template.HTML
-	BindRequest(req *http.Request)
+	BindRequest(req *http.Request) error
 	BindData(data map[string][]string, langs ...string)
+	SelectLocale(langs ...string)
 	IsBound() bool
+	HasChanged() bool
+	ChangedData() []string
 	Fields() []*Field
 	FieldByName(field string) (*Field, error)
+	AddField(fld FormField) error
+	InsertFieldAfter(after string, fld FormField) error
+	RemoveField(field string) error
+	OrderFields(names []string) error
 	IsValid() bool
 	CleanedData() CleanedData
 	Errors() FormErrors
+	NonFieldErrors() []Error
+	Warnings() FormErrors
 	SetCleanFunc(clean func(*Form))
+	SetCleanContextFunc(clean func(context.Context, *Form))
 	AddError(field string, err error) error
+	ErrorSummary() template.HTML
 	// CharField(name string) CharField
 	// EmailField(name string) EmailField
 }
@@ -32,15 +44,23 @@ type fieldInterface interface {
 	fieldRenderer
 	fieldReader
 	field() *Field
+	hasChanged(values []string) bool
 }
 
 type fieldInitializer interface {
 	SetLabel(label string)
 	MarkSafe()
 	SetHelpText(help string)
+	SetLabelT(labels map[language.Tag]string)
+	SetLabelKey(key string)
+	SetHelpTextT(helps map[language.Tag]string)
+	SetHelpTextKey(key string)
 	SetNotRequired()
 	SetDisabled()
+	SetLocalizedNumber()
+	SetLocalizedDate()
 	AddChoiceOptions(label string, options []ChoiceFieldOption)
+	AddWarning(warning error)
 	addError(err Error)
 }
 
@@ -55,6 +75,9 @@ type fieldCustomizer interface {
 	SetLocale(locale language.Tag)
 	SetSanitizeFunc(update func(current SanitizationFunc) (new SanitizationFunc))
 	SetValidateFunc(update func(current ValidationFunc) (new ValidationFunc))
+	SetValidateContextFunc(update func(current ValidationContextFunc) (new ValidationContextFunc))
+	AddValidators(validators ...Validator)
+	SetStopOnFirstError()
 }
 
 type fieldRenderer interface {
@@ -63,12 +86,14 @@ type fieldRenderer interface {
 	LegendTag() template.HTML
 	Widget() template.HTML
 	Errors() template.HTML
+	Warnings() template.HTML
 	HelpText() template.HTML
 }
 
 type fieldReader interface {
 	Name() string
 	HTMLName() string
+	Prefix() string
 	AutoID() string
 	LabelSuffix() string
 	UseFieldset() bool
@@ -76,4 +101,7 @@ type fieldReader interface {
 	Required() bool
 	HasHelpText() bool
 	HasErrors() bool
+	HasWarnings() bool
+	TranslateError(err Error) string
+	TranslateSummaryError(err Error) string
 }
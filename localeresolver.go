@@ -0,0 +1,113 @@
+package aform
+
+import (
+	"fmt"
+	"golang.org/x/text/language"
+	"net/http"
+	"strings"
+)
+
+// LocaleResolver resolves the locale requested by a request. ResolveLocale
+// returns a BCP 47 language tag or a list of tags formatted like the
+// Accept-Language header. It returns the empty string if req doesn't request
+// any locale.
+type LocaleResolver interface {
+	ResolveLocale(req *http.Request) string
+}
+
+// LocaleResolverFunc is an adapter to use ordinary functions as
+// LocaleResolver.
+type LocaleResolverFunc func(req *http.Request) string
+
+// ResolveLocale calls rf(req).
+func (rf LocaleResolverFunc) ResolveLocale(req *http.Request) string {
+	return rf(req)
+}
+
+// WithLocaleResolvers returns a FormOption that sets the chain of resolvers
+// used to select the locale of the form with the request given to
+// BindRequest or SetRequest. Resolvers are tried in order and the first
+// locale matching one of the locales set with WithLocales is selected. The
+// default chain contains only AcceptLanguageLocaleResolver. e.g.
+//
+//	WithLocaleResolvers(QueryLocaleResolver("lang"), CookieLocaleResolver("lang"), AcceptLanguageLocaleResolver())
+func WithLocaleResolvers(resolvers ...LocaleResolver) FormOption {
+	return func(f *Form) error {
+		if len(resolvers) == 0 {
+			return fmt.Errorf("locale resolvers can't be empty")
+		}
+		f.localeResolvers = resolvers
+		return nil
+	}
+}
+
+// AcceptLanguageLocaleResolver returns a LocaleResolver reading the
+// Accept-Language header.
+func AcceptLanguageLocaleResolver() LocaleResolver {
+	return LocaleResolverFunc(func(req *http.Request) string {
+		return req.Header.Get("Accept-Language")
+	})
+}
+
+// QueryLocaleResolver returns a LocaleResolver reading the URL query
+// parameter param. e.g. "fr" for /jobs?lang=fr with param "lang".
+func QueryLocaleResolver(param string) LocaleResolver {
+	return LocaleResolverFunc(func(req *http.Request) string {
+		return req.URL.Query().Get(param)
+	})
+}
+
+// CookieLocaleResolver returns a LocaleResolver reading the cookie name.
+func CookieLocaleResolver(name string) LocaleResolver {
+	return LocaleResolverFunc(func(req *http.Request) string {
+		cookie, err := req.Cookie(name)
+		if err != nil {
+			return ""
+		}
+		return cookie.Value
+	})
+}
+
+// PathPrefixLocaleResolver returns a LocaleResolver reading the first
+// segment of the URL path. e.g. "fr" for /fr/jobs. The segment is ignored if
+// it isn't a well-formed language tag.
+func PathPrefixLocaleResolver() LocaleResolver {
+	return LocaleResolverFunc(func(req *http.Request) string {
+		segment, _, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/"), "/")
+		if _, err := language.Parse(segment); err != nil {
+			return ""
+		}
+		return segment
+	})
+}
+
+// ContextLocaleResolver returns a LocaleResolver reading the value of key in
+// the request context. The value must be a string or a language.Tag. It is
+// useful when a middleware has already resolved the locale of the user.
+func ContextLocaleResolver(key interface{}) LocaleResolver {
+	return LocaleResolverFunc(func(req *http.Request) string {
+		switch value := req.Context().Value(key).(type) {
+		case string:
+			return value
+		case language.Tag:
+			return value.String()
+		default:
+			return ""
+		}
+	})
+}
+
+// resolveLocales returns the locales resolved for req by resolvers in
+// order. The default chain is used when resolvers is empty.
+func resolveLocales(req *http.Request, resolvers []LocaleResolver) []string {
+	if len(resolvers) == 0 {
+		resolvers = []LocaleResolver{AcceptLanguageLocaleResolver()}
+	}
+	var locales []string
+	for _, resolver := range resolvers {
+		if locale := resolver.ResolveLocale(req); len(locale) > 0 {
+			locales = append(locales, locale)
+		}
+	}
+	return locales
+}
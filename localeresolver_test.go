@@ -0,0 +1,203 @@
+package aform_test
+
+import (
+	"context"
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type localeContextKey struct{}
+
+func TestLocaleResolvers(t *testing.T) {
+	withContext := func(req *http.Request, value interface{}) *http.Request {
+		return req.WithContext(context.WithValue(req.Context(), localeContextKey{}, value))
+	}
+	tests := []struct {
+		name     string
+		resolver aform.LocaleResolver
+		req      func() *http.Request
+		want     string
+	}{
+		{
+			name:     "Accept-Language",
+			resolver: aform.AcceptLanguageLocaleResolver(),
+			req: func() *http.Request {
+				req := httptest.NewRequest("GET", "/", nil)
+				req.Header.Set("Accept-Language", "fr-CH, fr;q=0.9")
+				return req
+			},
+			want: "fr-CH, fr;q=0.9",
+		},
+		{
+			name:     "Query parameter",
+			resolver: aform.QueryLocaleResolver("lang"),
+			req:      func() *http.Request { return httptest.NewRequest("GET", "/jobs?lang=de", nil) },
+			want:     "de",
+		},
+		{
+			name:     "Missing query parameter",
+			resolver: aform.QueryLocaleResolver("lang"),
+			req:      func() *http.Request { return httptest.NewRequest("GET", "/jobs", nil) },
+			want:     "",
+		},
+		{
+			name:     "Cookie",
+			resolver: aform.CookieLocaleResolver("lang"),
+			req: func() *http.Request {
+				req := httptest.NewRequest("GET", "/", nil)
+				req.AddCookie(&http.Cookie{Name: "lang", Value: "it"})
+				return req
+			},
+			want: "it",
+		},
+		{
+			name:     "Missing cookie",
+			resolver: aform.CookieLocaleResolver("lang"),
+			req:      func() *http.Request { return httptest.NewRequest("GET", "/", nil) },
+			want:     "",
+		},
+		{
+			name:     "Path prefix",
+			resolver: aform.PathPrefixLocaleResolver(),
+			req:      func() *http.Request { return httptest.NewRequest("GET", "/pt-BR/jobs/42", nil) },
+			want:     "pt-BR",
+		},
+		{
+			name:     "Path prefix is not a language",
+			resolver: aform.PathPrefixLocaleResolver(),
+			req:      func() *http.Request { return httptest.NewRequest("GET", "/jobs/42", nil) },
+			want:     "",
+		},
+		{
+			name:     "Context string",
+			resolver: aform.ContextLocaleResolver(localeContextKey{}),
+			req:      func() *http.Request { return withContext(httptest.NewRequest("GET", "/", nil), "nl") },
+			want:     "nl",
+		},
+		{
+			name:     "Context tag",
+			resolver: aform.ContextLocaleResolver(localeContextKey{}),
+			req:      func() *http.Request { return withContext(httptest.NewRequest("GET", "/", nil), language.Spanish) },
+			want:     "es",
+		},
+		{
+			name:     "Context other type",
+			resolver: aform.ContextLocaleResolver(localeContextKey{}),
+			req:      func() *http.Request { return withContext(httptest.NewRequest("GET", "/", nil), 42) },
+			want:     "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.resolver.ResolveLocale(tt.req()))
+		})
+	}
+}
+
+func TestWithLocaleResolvers_BindRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		cookie string
+		accept string
+		want   string
+	}{
+		{name: "Query first", target: "/?lang=de", cookie: "fr", accept: "fr", want: aform.RequiredErrorMessageDe},
+		{name: "Cookie second", target: "/", cookie: "fr", accept: "de", want: aform.RequiredErrorMessageFr},
+		{name: "Unsupported query falls through", target: "/?lang=da", cookie: "fr", accept: "de", want: aform.RequiredErrorMessageFr},
+		{name: "Accept-Language last", target: "/", accept: "de", want: aform.RequiredErrorMessageDe},
+		{name: "Default locale", target: "/", want: aform.RequiredErrorMessageEn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			f := aform.Must(aform.New(
+				aform.WithLocales([]language.Tag{language.English, language.French, language.German}),
+				aform.WithLocaleResolvers(aform.QueryLocaleResolver("lang"), aform.CookieLocaleResolver("lang"), aform.AcceptLanguageLocaleResolver()),
+				aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))),
+			))
+			req := httptest.NewRequest("POST", tt.target, strings.NewReader(url.Values{"name": {""}}.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if len(tt.cookie) > 0 {
+				req.AddCookie(&http.Cookie{Name: "lang", Value: tt.cookie})
+			}
+			if len(tt.accept) > 0 {
+				req.Header.Set("Accept-Language", tt.accept)
+			}
+			a.NoError(f.BindRequest(req))
+			a.False(f.IsValid())
+			a.Contains(string(f.AsDiv()), tt.want)
+		})
+	}
+}
+
+func TestWithLocaleResolvers_SetRequest(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithLocales([]language.Tag{language.English, language.French, language.German}),
+		aform.WithLocaleResolvers(aform.PathPrefixLocaleResolver()),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Name",
+			aform.WithLabelT(map[language.Tag]string{language.French: "Nom", language.German: "Name"}),
+		))),
+	))
+	req := httptest.NewRequest("GET", "/fr/jobs", nil)
+	req.Header.Set("Accept-Language", "de")
+	f.SetRequest(req)
+	a.Contains(string(f.AsDiv()), ">Nom</label>")
+	f = aform.Must(aform.New(
+		aform.WithLocales([]language.Tag{language.English, language.French, language.German}),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Name",
+			aform.WithLabelT(map[language.Tag]string{language.French: "Nom", language.German: "Name"}),
+		))),
+	))
+	f.SetRequest(req)
+	a.Contains(string(f.AsDiv()), ">Name</label>", "Accept-Language is the default resolver")
+	_, err := aform.New(aform.WithLocaleResolvers())
+	a.Error(err)
+}
+
+func TestWithFormSetLocaleResolvers(t *testing.T) {
+	a := assert.New(t)
+	factory := func(index int) (*aform.Form, error) {
+		return aform.New(
+			aform.WithLocales([]language.Tag{language.English, language.French}),
+			aform.WithCharField(aform.Must(aform.DefaultCharField("Company"))),
+		)
+	}
+	fs := aform.Must(aform.NewFormSet(factory, aform.WithInitialForms(1), aform.WithFormSetLocaleResolvers(aform.QueryLocaleResolver("lang"))))
+	body := url.Values{"form-total_forms": {"1"}, "form-initial_forms": {"1"}, "form-0-company": {""}}
+	req := httptest.NewRequest("POST", "/?lang=fr", strings.NewReader(body.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	a.NoError(fs.BindRequest(req))
+	a.False(fs.IsValid())
+	a.Contains(string(fs.Forms()[0].AsDiv()), aform.RequiredErrorMessageFr)
+	_, err := aform.NewFormSet(factory, aform.WithFormSetLocaleResolvers())
+	a.Error(err)
+}
+
+func TestFormSet_SetRequest(t *testing.T) {
+	a := assert.New(t)
+	factory := func(index int) (*aform.Form, error) {
+		return aform.New(
+			aform.WithLocales([]language.Tag{language.English, language.French}),
+			aform.WithCharField(aform.Must(aform.DefaultCharField("Company", aform.WithLabelT(map[language.Tag]string{language.French: "Entreprise"})))),
+		)
+	}
+	fs := aform.Must(aform.NewFormSet(factory, aform.WithExtra(2), aform.WithFormSetLocaleResolvers(aform.PathPrefixLocaleResolver())))
+	req := httptest.NewRequest("GET", "/fr/experiences", nil)
+	req.Header.Set("Accept-Language", "en")
+	fs.SetRequest(req)
+	a.Equal(2, strings.Count(string(fs.AsDiv()), ">Entreprise</label>"))
+	empty, err := fs.EmptyForm()
+	a.NoError(err)
+	a.Contains(string(empty.AsDiv()), ">Entreprise</label>")
+	fs = aform.Must(aform.NewFormSet(factory))
+	fs.SetRequest(req)
+	a.Contains(string(fs.AsDiv()), ">Company</label>", "Accept-Language is the default resolver")
+}
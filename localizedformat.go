@@ -0,0 +1,348 @@
+package aform
+
+import (
+	"fmt"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Error codes of the values that can't be parsed with the locale of the
+// field. See IsLocalizedNumber and IsLocalizedDate.
+const (
+	NumberErrorCode = "number"
+	DateErrorCode   = "date"
+)
+
+// English and French error messages of the values that can't be parsed with
+// the locale of the field.
+const (
+	NumberErrorMessageEn = "Enter a number"
+	NumberErrorMessageFr = "Entrez un nombre"
+	DateErrorMessageEn   = "Enter a valid date"
+	DateErrorMessageFr   = "Entrez une date valide"
+)
+
+// DateLayout is the layout of the cleaned data of the fields created with
+// IsLocalizedDate.
+const DateLayout = "2006-01-02"
+
+// IsLocalizedNumber returns a FieldOption that parses the value of the Field
+// as a number written with the separators of the locale of the Field. e.g.
+// "1 234,56" in French and "1,234.56" in English. The cleaned data is the
+// canonical number "1234.56", without leading zeros of the integer part and
+// trailing zeros of the fractional part. Bound values are rendered back with
+// the separators of the locale. If the value can't be parsed, an error with
+// the code NumberErrorCode is returned. It is used by CharField with a
+// TextInput widget. It returns an error if the Field is not a CharField.
+func IsLocalizedNumber() FieldOption {
+	return func(fld *Field) error {
+		if err := checkLocalizable(fld); err != nil {
+			return err
+		}
+		fld.SetLocalizedNumber()
+		return nil
+	}
+}
+
+// IsLocalizedDate returns a FieldOption that parses the value of the Field as
+// a date written in the order of the locale of the Field. e.g. "16/10/2026"
+// in French and "10/16/2026" in English. The year must have four digits. The
+// cleaned data is the date formatted with DateLayout, e.g. "2026-10-16", and
+// bound values are rendered back in the format of the locale. If the value
+// can't be parsed, an error with the code DateErrorCode is returned. It is
+// used by CharField with a TextInput widget. It returns an error if the Field
+// is not a CharField.
+func IsLocalizedDate() FieldOption {
+	return func(fld *Field) error {
+		if err := checkLocalizable(fld); err != nil {
+			return err
+		}
+		fld.SetLocalizedDate()
+		return nil
+	}
+}
+
+// SetLocalizedNumber parses the value of the Field as a number written with
+// the separators of the locale of the Field. See IsLocalizedNumber. It has no
+// effect if the Field is not a CharField.
+func (fld *Field) SetLocalizedNumber() {
+	if checkLocalizable(fld) == nil {
+		fld.localized = numberFormat{}
+	}
+}
+
+// SetLocalizedDate parses the value of the Field as a date written in the
+// order of the locale of the Field. See IsLocalizedDate. It has no effect if
+// the Field is not a CharField.
+func (fld *Field) SetLocalizedDate() {
+	if checkLocalizable(fld) == nil {
+		fld.localized = dateFormat{}
+	}
+}
+
+// checkLocalizable returns an error if the values of fld can't be parsed
+// with its locale. Only CharField parses them.
+func checkLocalizable(fld *Field) error {
+	if fld.fieldType != CharFieldType {
+		return fmt.Errorf("%s field of type %s can't be localized, only a %s can", fld.name, fld.fieldType, CharFieldType)
+	}
+	return nil
+}
+
+// localizedFormat parses values written in a locale into their canonical
+// representation and formats canonical values back.
+type localizedFormat interface {
+	parse(value string, locale language.Tag) (string, bool)
+	format(canonical string, locale language.Tag) (string, bool)
+	err() Error
+}
+
+// delocalize returns the canonical representation of value. If value can't
+// be parsed, the error of the format is returned and value is rendered as
+// is.
+func (fld *Field) delocalize(value string) (string, []Error) {
+	canonical, ok := fld.localized.parse(value, fld.locale)
+	if !ok {
+		fld.localizedRaw = true
+		return value, []Error{fld.localized.err()}
+	}
+	fld.localizedRaw = false
+	fld.boundValues = []string{canonical}
+	return canonical, nil
+}
+
+// localizeValue returns the canonical value formatted with the locale of the
+// field. Values that are not canonical are returned as is.
+func (fld *Field) localizeValue(value string) string {
+	if fld.localized == nil || fld.localizedRaw {
+		return value
+	}
+	if formatted, ok := fld.localized.format(value, fld.locale); ok {
+		return formatted
+	}
+	return value
+}
+
+// localizeValues formats canonical values with the locale of the field.
+func (fld *Field) localizeValues(values []string) []string {
+	if fld.localized == nil {
+		return values
+	}
+	localized := make([]string, len(values))
+	for i, value := range values {
+		if formatted, ok := fld.localized.format(value, fld.locale); ok {
+			localized[i] = formatted
+		} else {
+			localized[i] = value
+		}
+	}
+	return localized
+}
+
+var canonicalNumberRegexp = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+type numberFormat struct{}
+
+func (numberFormat) err() Error {
+	return ErrorWrap(simpleError{code: NumberErrorCode, fr: NumberErrorMessageFr, en: NumberErrorMessageEn})
+}
+
+func (numberFormat) parse(value string, locale language.Tag) (string, bool) {
+	group, decimal := numberSymbols(locale)
+	value = strings.TrimSpace(value)
+	sign := ""
+	for _, minus := range []string{"-", "\u2212"} {
+		if strings.HasPrefix(value, minus) {
+			sign, value = "-", strings.TrimPrefix(value, minus)
+			break
+		}
+	}
+	integer, fraction, hasFraction := strings.Cut(value, decimal)
+	integer = groupReplacer(group).Replace(integer)
+	if !isDigits(integer) || (hasFraction && !isDigits(fraction)) {
+		return "", false
+	}
+	integer = strings.TrimLeft(integer, "0")
+	if len(integer) == 0 {
+		integer = "0"
+	}
+	fraction = strings.TrimRight(fraction, "0")
+	if integer == "0" && len(fraction) == 0 {
+		return "0", true
+	}
+	if len(fraction) > 0 {
+		return sign + integer + "." + fraction, true
+	}
+	return sign + integer, true
+}
+
+// format renders canonical with the symbols of locale. The integer part is
+// grouped by golang.org/x/text when it fits in an int64, or by groups of
+// three digits otherwise. The fraction digits are kept as they are, so no
+// precision is lost.
+func (numberFormat) format(canonical string, locale language.Tag) (string, bool) {
+	if !canonicalNumberRegexp.MatchString(canonical) {
+		return "", false
+	}
+	sign, value := "", canonical
+	if strings.HasPrefix(value, "-") {
+		sign, value = "-", strings.TrimPrefix(value, "-")
+	}
+	group, decimal := numberSymbols(locale)
+	integer, fraction, hasFraction := strings.Cut(value, ".")
+	if i, err := strconv.ParseInt(integer, 10, 64); err == nil {
+		integer = message.NewPrinter(locale).Sprint(number.Decimal(i))
+	} else {
+		integer = groupDigits(integer, group)
+	}
+	if hasFraction {
+		return sign + integer + decimal + fraction, true
+	}
+	return sign + integer, true
+}
+
+// groupDigits inserts group between each group of three digits of integer.
+func groupDigits(integer, group string) string {
+	var b strings.Builder
+	for i, r := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(group)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// numberSymbols returns the group and decimal separators of locale. They are
+// read in a number formatted with golang.org/x/text.
+func numberSymbols(locale language.Tag) (group, decimal string) {
+	formatted := message.NewPrinter(locale).Sprint(number.Decimal(1234567.891))
+	separators := strings.FieldsFunc(formatted, unicode.IsDigit)
+	if len(separators) < 2 {
+		return ",", "."
+	}
+	return separators[0], separators[len(separators)-1]
+}
+
+// groupReplacer removes the group separator. Users type a regular space or
+// an apostrophe when the separator of the locale is a non-breaking space or
+// a typographic apostrophe.
+func groupReplacer(group string) *strings.Replacer {
+	switch group {
+	case " ", "\u00a0", "\u202f":
+		return strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "")
+	case "'", "\u2019":
+		return strings.NewReplacer("'", "", "\u2019", "")
+	default:
+		return strings.NewReplacer(group, "")
+	}
+}
+
+func isDigits(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+type dateFormat struct{}
+
+func (dateFormat) err() Error {
+	return ErrorWrap(simpleError{code: DateErrorCode, fr: DateErrorMessageFr, en: DateErrorMessageEn})
+}
+
+func (dateFormat) parse(value string, locale language.Tag) (string, bool) {
+	if strings.IndexFunc(value, unicode.IsLetter) >= 0 {
+		return "", false
+	}
+	parts := strings.FieldsFunc(value, func(r rune) bool { return !unicode.IsDigit(r) })
+	order, _ := dateOrder(locale)
+	if len(parts) != len(order) {
+		return "", false
+	}
+	var year, month, day int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return "", false
+		}
+		switch order[i] {
+		case 'y':
+			if len(part) != 4 {
+				return "", false
+			}
+			year = n
+		case 'm':
+			if len(part) > 2 {
+				return "", false
+			}
+			month = n
+		case 'd':
+			if len(part) > 2 {
+				return "", false
+			}
+			day = n
+		}
+	}
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if t.Year() != year || int(t.Month()) != month || t.Day() != day {
+		return "", false
+	}
+	return t.Format(DateLayout), true
+}
+
+func (dateFormat) format(canonical string, locale language.Tag) (string, bool) {
+	t, err := time.Parse(DateLayout, canonical)
+	if err != nil {
+		return "", false
+	}
+	order, separator := dateOrder(locale)
+	parts := make([]string, len(order))
+	for i, c := range order {
+		switch c {
+		case 'y':
+			parts[i] = fmt.Sprintf("%04d", t.Year())
+		case 'm':
+			parts[i] = fmt.Sprintf("%02d", t.Month())
+		case 'd':
+			parts[i] = fmt.Sprintf("%02d", t.Day())
+		}
+	}
+	return strings.Join(parts, separator), true
+}
+
+// dateOrder returns the order of the year, month and day of locale, e.g.
+// "dmy", and the separator between them. They are read in a date formatted
+// with the short date format of the go-playground translator of locale.
+func dateOrder(locale language.Tag) (string, string) {
+	formatted := localeTranslator(locale).FmtDateShort(time.Date(2033, 11, 22, 0, 0, 0, 0, time.UTC))
+	indexes := map[byte]int{
+		'y': strings.Index(formatted, "33"),
+		'm': strings.Index(formatted, "11"),
+		'd': strings.Index(formatted, "22"),
+	}
+	order := []byte("ymd")
+	for _, index := range indexes {
+		if index < 0 {
+			return "ymd", "-"
+		}
+	}
+	sort.Slice(order, func(i, j int) bool { return indexes[order[i]] < indexes[order[j]] })
+	separators := strings.FieldsFunc(formatted, unicode.IsDigit)
+	if len(separators) == 0 {
+		return string(order), "-"
+	}
+	return string(order), separators[0]
+}
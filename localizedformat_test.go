@@ -0,0 +1,161 @@
+package aform_test
+
+import (
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"testing"
+)
+
+func TestIsLocalizedNumber(t *testing.T) {
+	tests := []struct {
+		name         string
+		locale       string
+		value        string
+		wantCleaned  string
+		wantRendered string
+		wantErr      string
+	}{
+		{name: "French", locale: "fr", value: "1 234,56", wantCleaned: "1234.56", wantRendered: "1 234,56"},
+		{name: "French with non-breaking space", locale: "fr", value: "1 234,5", wantCleaned: "1234.5", wantRendered: "1 234,5"},
+		{name: "French without group", locale: "fr", value: "-1234,50", wantCleaned: "-1234.5", wantRendered: "-1 234,5"},
+		{name: "English", locale: "en", value: "1,234.56", wantCleaned: "1234.56", wantRendered: "1,234.56"},
+		{name: "English integer", locale: "en", value: "007", wantCleaned: "7", wantRendered: "7"},
+		{name: "English zero", locale: "en", value: "-0.00", wantCleaned: "0", wantRendered: "0"},
+		{name: "German", locale: "de", value: "1.234,56", wantCleaned: "1234.56", wantRendered: "1.234,56"},
+		{name: "Swiss German", locale: "de-CH", value: "1'234.56", wantCleaned: "1234.56", wantRendered: "1’234.56"},
+		{name: "French with English separators", locale: "fr", value: "1234.56", wantRendered: "1234.56", wantErr: aform.NumberErrorCode},
+		{name: "Not a number", locale: "en", value: "12a", wantRendered: "12a", wantErr: aform.NumberErrorCode},
+		{name: "Empty fraction", locale: "en", value: "12.", wantRendered: "12.", wantErr: aform.NumberErrorCode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			f := aform.Must(aform.New(
+				aform.WithLocales([]language.Tag{language.English, language.French, language.German, language.Dutch, language.MustParse("de-CH")}),
+				aform.WithCharField(aform.Must(aform.NewCharField("Value", "", "", 0, 0, aform.IsLocalizedNumber()))),
+			))
+			f.BindData(map[string][]string{"value": {tt.value}}, tt.locale)
+			if len(tt.wantErr) > 0 {
+				a.False(f.IsValid())
+				a.Equal(tt.wantErr, f.Errors().Get("value").Code())
+			} else {
+				a.True(f.IsValid())
+				a.Equal(tt.wantCleaned, f.CleanedData().Get("value"))
+			}
+			fld, err := f.FieldByName("Value")
+			a.NoError(err)
+			a.Contains(string(fld.Widget()), `value="`+tt.wantRendered+`"`)
+		})
+	}
+}
+
+func TestIsLocalizedDate(t *testing.T) {
+	tests := []struct {
+		name         string
+		locale       string
+		value        string
+		wantCleaned  string
+		wantRendered string
+		wantErr      string
+	}{
+		{name: "French", locale: "fr", value: "16/10/2026", wantCleaned: "2026-10-16", wantRendered: "16/10/2026"},
+		{name: "French without zero", locale: "fr", value: "1/2/2026", wantCleaned: "2026-02-01", wantRendered: "01/02/2026"},
+		{name: "English", locale: "en", value: "10/16/2026", wantCleaned: "2026-10-16", wantRendered: "10/16/2026"},
+		{name: "German", locale: "de", value: "16.10.2026", wantCleaned: "2026-10-16", wantRendered: "16.10.2026"},
+		{name: "Dutch", locale: "nl", value: "16-10-2026", wantCleaned: "2026-10-16", wantRendered: "16-10-2026"},
+		{name: "English with French order", locale: "en", value: "16/10/2026", wantRendered: "16/10/2026", wantErr: aform.DateErrorCode},
+		{name: "Invalid day", locale: "fr", value: "31/02/2026", wantRendered: "31/02/2026", wantErr: aform.DateErrorCode},
+		{name: "Two digits year", locale: "fr", value: "16/10/26", wantRendered: "16/10/26", wantErr: aform.DateErrorCode},
+		{name: "Letters", locale: "fr", value: "16 oct 2026", wantRendered: "16 oct 2026", wantErr: aform.DateErrorCode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			f := aform.Must(aform.New(
+				aform.WithLocales([]language.Tag{language.English, language.French, language.German, language.Dutch, language.MustParse("de-CH")}),
+				aform.WithCharField(aform.Must(aform.NewCharField("Value", "", "", 0, 0, aform.IsLocalizedDate()))),
+			))
+			f.BindData(map[string][]string{"value": {tt.value}}, tt.locale)
+			if len(tt.wantErr) > 0 {
+				a.False(f.IsValid())
+				a.Equal(tt.wantErr, f.Errors().Get("value").Code())
+			} else {
+				a.True(f.IsValid())
+				a.Equal(tt.wantCleaned, f.CleanedData().Get("value"))
+			}
+			fld, err := f.FieldByName("Value")
+			a.NoError(err)
+			a.Contains(string(fld.Widget()), `value="`+tt.wantRendered+`"`)
+		})
+	}
+}
+
+func TestIsLocalizedNumber_initialValue(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithLocales([]language.Tag{language.English, language.French, language.German, language.Dutch, language.MustParse("de-CH")}),
+		aform.WithCharField(aform.Must(aform.NewCharField("Value", "1234.5", "", 0, 0, aform.IsLocalizedNumber()))),
+	))
+	f.SelectLocale("fr")
+	fld, _ := f.FieldByName("Value")
+	a.Contains(string(fld.Widget()), "value=\"1 234,5\"")
+	f = aform.Must(aform.New(
+		aform.WithLocales([]language.Tag{language.English, language.French, language.German, language.Dutch, language.MustParse("de-CH")}),
+		aform.WithCharField(aform.Must(aform.NewCharField("Value", "1234.5", "", 0, 0, aform.IsLocalizedNumber()))),
+	))
+	f.BindData(map[string][]string{"value": {"1 234,50"}}, "fr")
+	a.False(f.HasChanged())
+	a.True(f.IsValid())
+}
+
+func TestIsLocalizedNumber_initialValuePrecision(t *testing.T) {
+	tests := []struct {
+		locale       string
+		initial      string
+		wantRendered string
+	}{
+		{locale: "en", initial: "12345678901234567.25", wantRendered: "12,345,678,901,234,567.25"},
+		{locale: "en", initial: "-0.125", wantRendered: "-0.125"},
+		{locale: "de", initial: "123456789012345678901234.5", wantRendered: "123.456.789.012.345.678.901.234,5"},
+		{locale: "de", initial: "-98765432109876543210", wantRendered: "-98.765.432.109.876.543.210"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.initial, func(t *testing.T) {
+			a := assert.New(t)
+			f := aform.Must(aform.New(
+				aform.WithLocales([]language.Tag{language.English, language.French, language.German, language.Dutch, language.MustParse("de-CH")}),
+				aform.WithCharField(aform.Must(aform.NewCharField("Value", tt.initial, "", 0, 0, aform.IsLocalizedNumber()))),
+			))
+			f.SelectLocale(tt.locale)
+			fld, _ := f.FieldByName("Value")
+			a.Contains(string(fld.Widget()), `value="`+tt.wantRendered+`"`)
+		})
+	}
+}
+
+func TestIsLocalizedNumber_validators(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithLocales([]language.Tag{language.English, language.French}),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Count", aform.IsLocalizedNumber(), aform.WithValidators(aform.Integer())))),
+	))
+	f.BindData(map[string][]string{"count": {"12 000"}}, "fr")
+	a.True(f.IsValid())
+	a.Equal("12000", f.CleanedData().Get("count"))
+	f = aform.Must(aform.New(
+		aform.WithLocales([]language.Tag{language.English, language.French}),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("Count", aform.IsLocalizedNumber(), aform.WithValidators(aform.Integer())))),
+	))
+	f.BindData(map[string][]string{"count": {"12,5"}}, "fr")
+	a.False(f.IsValid())
+	a.Equal(aform.IntegerErrorCode, f.Errors().Get("count").Code())
+}
+
+func TestIsLocalizedNumber_unsupportedField(t *testing.T) {
+	a := assert.New(t)
+	_, err := aform.DefaultEmailField("Email", aform.IsLocalizedNumber())
+	a.EqualError(err, "Email field of type EmailField can't be localized, only a CharField can")
+	_, err = aform.DefaultChoiceField("Start", aform.IsLocalizedDate())
+	a.EqualError(err, "Start field of type ChoiceField can't be localized, only a CharField can")
+}
@@ -0,0 +1,117 @@
+package aform
+
+import (
+	"golang.org/x/text/language"
+)
+
+// WithLabelT returns a FieldOption that sets a label translated in several
+// languages. The label is resolved with the locale of the Field. e.g.
+//
+//	WithLabelT(map[language.Tag]string{language.English: "First name", language.French: "Prénom"})
+func WithLabelT(labels map[language.Tag]string) FieldOption {
+	return func(fld *Field) error {
+		fld.SetLabelT(labels)
+		return nil
+	}
+}
+
+// WithLabelKey returns a FieldOption that sets a label looked up in the
+// messages registered with RegisterMessages. key is the message key without
+// TextKeyPrefix.
+func WithLabelKey(key string) FieldOption {
+	return func(fld *Field) error {
+		fld.SetLabelKey(key)
+		return nil
+	}
+}
+
+// WithHelpTextT returns a FieldOption that sets a help text translated in
+// several languages. Help text is not HTML-escaped.
+func WithHelpTextT(helps map[language.Tag]string) FieldOption {
+	return func(fld *Field) error {
+		fld.SetHelpTextT(helps)
+		return nil
+	}
+}
+
+// WithHelpTextKey returns a FieldOption that sets a help text looked up in
+// the messages registered with RegisterMessages. key is the message key
+// without TextKeyPrefix.
+// Help text is not HTML-escaped.
+func WithHelpTextKey(key string) FieldOption {
+	return func(fld *Field) error {
+		fld.SetHelpTextKey(key)
+		return nil
+	}
+}
+
+// SetLabelT sets a label translated in several languages. The label is
+// resolved with the locale of the Field following its fallback chain, e.g.
+// "fr-CA", "fr" and "en". If there is no label for any locale of the chain,
+// the label set with SetLabel is used.
+func (fld *Field) SetLabelT(labels map[language.Tag]string) {
+	fld.labelT = localizedText{translations: labels}
+}
+
+// SetLabelKey sets a label looked up in the messages registered with
+// RegisterMessages. key is the message key without TextKeyPrefix. The label is resolved with the
+// locale of the Field following its fallback chain. If there is no message
+// for any locale of the chain, key is used.
+func (fld *Field) SetLabelKey(key string) {
+	fld.labelT = localizedText{key: key}
+}
+
+// SetHelpTextT sets a help text translated in several languages. It is
+// resolved like the label set with SetLabelT. Help text is not HTML-escaped.
+func (fld *Field) SetHelpTextT(helps map[language.Tag]string) {
+	fld.helpTextT = localizedText{translations: helps}
+}
+
+// SetHelpTextKey sets a help text looked up in the messages registered with
+// RegisterMessages. It is resolved like the label set with SetLabelKey. Help
+// text is not HTML-escaped.
+func (fld *Field) SetHelpTextKey(key string) {
+	fld.helpTextT = localizedText{key: key}
+}
+
+// localizedLabel returns the label resolved with the locale of the field.
+func (fld *Field) localizedLabel() string {
+	return fld.labelT.resolve(fld.locale, fld.label)
+}
+
+// localizedHelpText returns the help text resolved with the locale of the
+// field.
+func (fld *Field) localizedHelpText() string {
+	return fld.helpTextT.resolve(fld.locale, fld.helpText)
+}
+
+// localizedText is a text translated with a map of translations or with a
+// message key of the catalog.
+type localizedText struct {
+	translations map[language.Tag]string
+	key          string
+}
+
+// resolve returns the text of the first locale of the fallback chain of
+// locale having one. If there is none, it returns the key if it is set and
+// text otherwise.
+func (t localizedText) resolve(locale language.Tag, text string) string {
+	if len(t.key) == 0 && len(t.translations) == 0 {
+		return text
+	}
+	for _, tag := range localeChain(locale.String()) {
+		if len(t.key) > 0 {
+			if message, ok := lookupText(tag, t.key); ok {
+				return message
+			}
+			continue
+		}
+		if translation, ok := t.translations[tag]; ok {
+			return translation
+		}
+	}
+	if len(t.key) > 0 {
+		return t.key
+	}
+	return text
+}
@@ -0,0 +1,128 @@
+package aform_test
+
+import (
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"net/http/httptest"
+	"testing"
+)
+
+// registerCityMessages registers the English and French texts of the city
+// field until the end of the test t.
+func registerCityMessages(t *testing.T) {
+	t.Helper()
+	keys := []string{aform.TextKeyPrefix + "label_city", aform.TextKeyPrefix + "help_city"}
+	if err := aform.RegisterMessages(language.English, aform.Messages{keys[0]: "City", keys[1]: "Where you live"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := aform.RegisterMessages(language.French, aform.Messages{keys[0]: "Ville", keys[1]: "Où vous habitez"}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		aform.ExportDeleteMessages(language.English, keys...)
+		aform.ExportDeleteMessages(language.French, keys...)
+	})
+}
+
+func TestLocalizedText(t *testing.T) {
+	registerCityMessages(t)
+	tests := []struct {
+		name   string
+		locale string
+		want   []string
+	}{
+		{
+			name:   "English",
+			locale: "en",
+			want:   []string{">First name</label>", ">As on your passport</span>", ">City</label>", ">Where you live</span>", ">Red</option>", ">color_blue</option>"},
+		},
+		{
+			name:   "French",
+			locale: "fr",
+			want:   []string{">Prénom</label>", ">Comme sur votre passeport</span>", ">Ville</label>", ">Où vous habitez</span>", ">Rouge</option>", ">color_blue</option>"},
+		},
+		{
+			name:   "Regional French",
+			locale: "fr-CA",
+			want:   []string{">Prénom</label>", ">Comme sur votre passeport</span>", ">Ville</label>", ">Où vous habitez</span>", ">Rouge</option>"},
+		},
+		{
+			name:   "German falls back to English",
+			locale: "de",
+			want:   []string{">First name</label>", ">As on your passport</span>", ">City</label>", ">Where you live</span>", ">Red</option>"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			f := aform.Must(aform.New(
+				aform.WithLocales([]language.Tag{language.English, language.French, language.German}),
+				aform.WithCharField(aform.Must(aform.DefaultCharField("first_name",
+					aform.WithLabelT(map[language.Tag]string{language.English: "First name", language.French: "Prénom"}),
+					aform.WithHelpTextT(map[language.Tag]string{language.English: "As on your passport", language.French: "Comme sur votre passeport"}),
+				))),
+				aform.WithCharField(aform.Must(aform.DefaultCharField("city",
+					aform.WithLabelKey("label_city"),
+					aform.WithHelpTextKey("help_city"),
+				))),
+				aform.WithChoiceField(aform.Must(aform.DefaultChoiceField("color", aform.WithChoiceOptions([]aform.ChoiceFieldOption{
+					{Value: "red", Label: "Red", LabelT: map[language.Tag]string{language.French: "Rouge"}},
+					{Value: "blue", Label: "Blue", LabelKey: "color_blue"},
+				})))),
+			))
+			f.BindData(map[string][]string{"first_name": {"Jane"}, "city": {"Paris"}, "color": {"red"}}, tt.locale)
+			html := string(f.AsDiv())
+			for _, want := range tt.want {
+				a.Contains(html, want)
+			}
+		})
+	}
+}
+
+func TestForm_SelectLocale(t *testing.T) {
+	a := assert.New(t)
+	registerCityMessages(t)
+	f := aform.Must(aform.New(
+		aform.WithLocales([]language.Tag{language.English, language.French}),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("first_name", aform.WithLabelT(map[language.Tag]string{language.English: "First name", language.French: "Prénom"})))),
+	))
+	f.SelectLocale("fr")
+	a.False(f.IsBound())
+	a.Contains(string(f.AsDiv()), ">Prénom</label>")
+	f = aform.Must(aform.New(
+		aform.WithLocales([]language.Tag{language.English, language.French}),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("city", aform.WithLabelKey("label_city")))),
+	))
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Language", "fr-CH, fr;q=0.9, en;q=0.8")
+	f.SetRequest(req)
+	a.Contains(string(f.AsDiv()), ">Ville</label>")
+}
+
+func TestField_SetLabel_replacesLabelT(t *testing.T) {
+	a := assert.New(t)
+	fld := aform.Must(aform.DefaultCharField("first_name", aform.WithLabelT(map[language.Tag]string{language.English: "First name"})))
+	a.Equal(`<label for="id_first_name">First name</label>`, string(fld.LabelTag()))
+	fld.SetLabel("Given name")
+	a.Equal(`<label for="id_first_name">Given name</label>`, string(fld.LabelTag()))
+	fld.SetLabelT(map[language.Tag]string{language.French: "Prénom"})
+	a.Equal(`<label for="id_first_name">Given name</label>`, string(fld.LabelTag()))
+	fld.SetHelpTextT(map[language.Tag]string{language.French: "Prénom"})
+	a.False(fld.HasHelpText())
+	fld.SetLocale(language.French)
+	a.True(fld.HasHelpText())
+}
+
+func TestFieldsEqual_localizedLabel(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithLocales([]language.Tag{language.English, language.French}),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("password", aform.WithLabelT(map[language.Tag]string{language.French: "Mot de passe"})))),
+		aform.WithCharField(aform.Must(aform.DefaultCharField("confirmation"))),
+		aform.FieldsEqual("password", "confirmation"),
+	))
+	f.BindData(map[string][]string{"password": {"a"}, "confirmation": {"b"}}, "fr")
+	a.False(f.IsValid())
+	a.Equal("Assurez-vous que cette valeur correspond à Mot de passe", f.Errors()["confirmation"][0].Translate("fr"))
+}
@@ -71,7 +71,7 @@ func (fld *MultipleChoiceField) Clean(values []string) ([]string, []Error) {
 	}
 	var allErrors []Error
 	for _, sanitizedValue := range sanitizedValues {
-		errors := fld.validateFunc(sanitizedValue, false)
+		errors := fld.validate(sanitizedValue, false)
 		if len(errors) > 0 {
 			allErrors = append(allErrors, customizeErrors(errors, fld.customErrors)...)
 		}
@@ -80,6 +80,16 @@ func (fld *MultipleChoiceField) Clean(values []string) ([]string, []Error) {
 	return sanitizedValues, fld.errors
 }
 
+// hasChanged returns true if the set of non-empty sanitized values is
+// different from the set of initial values. Order doesn't matter.
+func (fld *MultipleChoiceField) hasChanged(values []string) bool {
+	sanitizedValues := make([]string, 0, len(values))
+	for _, value := range values {
+		sanitizedValues = append(sanitizedValues, fld.sanitize(value))
+	}
+	return !sameValueSet(sanitizedValues, fld.initialValues)
+}
+
 // EmptyValue returns the MultipleChoiceField empty value. The empty value is the
 // cleaned value returned by Clean when there is no data bound to the field.
 // A MultipleChoiceField empty value is always an empty slice.
@@ -104,6 +114,30 @@ func multipleChoiceFieldValidationFunc(fld *MultipleChoiceField) func(string, bo
 	}
 }
 
+// sameValueSet returns true if a and b contain the same non-empty values,
+// regardless of order and duplicates.
+func sameValueSet(a, b []string) bool {
+	set := func(values []string) map[string]struct{} {
+		output := map[string]struct{}{}
+		for _, value := range values {
+			if len(value) > 0 {
+				output[value] = struct{}{}
+			}
+		}
+		return output
+	}
+	setA, setB := set(a), set(b)
+	if len(setA) != len(setB) {
+		return false
+	}
+	for value := range setA {
+		if _, ok := setB[value]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func multipleChoiceCleanShouldReturnEmptyValue(values []string, required bool) bool {
 	noValue := true
 	for _, value := range values {
@@ -0,0 +1,80 @@
+package aform
+
+import (
+	"fmt"
+)
+
+// OneTimeTokenFieldName is the name of the hidden field rendered by a form
+// created with WithOneTimeToken.
+const OneTimeTokenFieldName = "form_token"
+
+// OneTimeTokenErrorCode is the error code of the error added when the
+// one-time token of a form is missing, unknown or already used.
+const OneTimeTokenErrorCode = "token_used"
+
+// English and French error messages of an invalid one-time token.
+const (
+	OneTimeTokenErrorMessageEn = "This form has already been submitted"
+	OneTimeTokenErrorMessageFr = "Ce formulaire a déjà été envoyé"
+)
+
+// WithOneTimeToken returns a FormOption that prevents a form from being
+// submitted twice, e.g. after a double click. When an unbound form is
+// rendered, AsDiv issues a token with store and renders it in a hidden field
+// named OneTimeTokenFieldName. The token is consumed during validation if all
+// the other validations succeed. If it is missing, unknown or already used,
+// an error with the code OneTimeTokenErrorCode is added to the non-field
+// errors. A form not valid for another reason renders its submitted token
+// again. Errors added with AddError after validation don't prevent the token
+// from being consumed.
+func WithOneTimeToken(store TokenStore) FormOption {
+	return func(f *Form) error {
+		if store == nil {
+			return fmt.Errorf("token store can't be nil")
+		}
+		f.tokenStore = store
+		return nil
+	}
+}
+
+func (f *Form) oneTimeTokenEnabled() bool {
+	return f.tokenStore != nil
+}
+
+func (f *Form) oneTimeTokenFieldName() string {
+	return prefixedName(f.prefix, OneTimeTokenFieldName)
+}
+
+// oneTimeTokenValue returns the token to render. The submitted token is
+// rendered again if it has not been rejected. Otherwise, or if the form is
+// restored by a Wizard, a new token is issued once per form. If the store
+// fails, no token is rendered and the next submission is rejected.
+func (f *Form) oneTimeTokenValue() string {
+	if f.bound && !f.tokenRejected && !f.restored {
+		return f.protectionData[f.oneTimeTokenFieldName()]
+	}
+	if len(f.issuedToken) == 0 {
+		token, err := f.tokenStore.Issue()
+		if err != nil {
+			return ""
+		}
+		f.issuedToken = token
+	}
+	return f.issuedToken
+}
+
+// oneTimeTokenErrors consumes the submitted token. It returns nil if the
+// protection is disabled or if the token is consumed successfully.
+func (f *Form) oneTimeTokenErrors() []Error {
+	if !f.oneTimeTokenEnabled() {
+		return nil
+	}
+	token := f.protectionData[f.oneTimeTokenFieldName()]
+	if len(token) > 0 {
+		if ok, err := f.tokenStore.Consume(token); ok && err == nil {
+			return nil
+		}
+	}
+	f.tokenRejected = true
+	return []Error{ErrorWrap(simpleError{code: OneTimeTokenErrorCode, fr: OneTimeTokenErrorMessageFr, en: OneTimeTokenErrorMessageEn})}
+}
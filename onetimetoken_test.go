@@ -0,0 +1,63 @@
+package aform_test
+
+import (
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+var oneTimeTokenPattern = regexp.MustCompile(`<input type="hidden" name="form_token" value="([^"]+)">`)
+
+func renderedOneTimeToken(t *testing.T, f *aform.Form) string {
+	matches := oneTimeTokenPattern.FindStringSubmatch(string(f.AsDiv()))
+	if len(matches) != 2 {
+		t.Fatalf("one-time token not found in %s", f.AsDiv())
+	}
+	return matches[1]
+}
+
+func TestWithOneTimeToken(t *testing.T) {
+	a := assert.New(t)
+	store := aform.NewMemoryTokenStore(0)
+	newForm := func() *aform.Form {
+		return aform.Must(aform.New(aform.WithOneTimeToken(store), aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+	}
+	unbound := newForm()
+	token := renderedOneTimeToken(t, unbound)
+	a.Equal(token, renderedOneTimeToken(t, unbound), "a form issues a single token")
+
+	// An invalid submission doesn't consume the token and renders it again.
+	f := newForm()
+	f.BindData(map[string][]string{"name": {""}, aform.OneTimeTokenFieldName: {token}})
+	a.False(f.IsValid())
+	a.Empty(f.NonFieldErrors())
+	a.Equal(token, renderedOneTimeToken(t, f))
+
+	// The first valid submission consumes the token.
+	f = newForm()
+	f.BindData(map[string][]string{"name": {"Jane"}, aform.OneTimeTokenFieldName: {token}})
+	a.True(f.IsValid())
+
+	// The second submission is rejected and a new token is rendered.
+	f = newForm()
+	f.BindData(map[string][]string{"name": {"Jane"}, aform.OneTimeTokenFieldName: {token}})
+	a.False(f.IsValid())
+	if a.Len(f.NonFieldErrors(), 1) {
+		a.Equal(aform.OneTimeTokenErrorCode, f.NonFieldErrors()[0].Code())
+	}
+	a.NotEqual(token, renderedOneTimeToken(t, f))
+}
+
+func TestWithOneTimeToken_missingToken(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithOneTimeToken(aform.NewMemoryTokenStore(0)), aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+	f.BindData(map[string][]string{"name": {"Jane"}})
+	a.False(f.IsValid())
+	a.Equal(aform.OneTimeTokenErrorCode, f.Errors().Get(aform.NonFieldErrorsKey).Code())
+}
+
+func TestWithOneTimeToken_nilStore(t *testing.T) {
+	_, err := aform.New(aform.WithOneTimeToken(nil))
+	assert.Error(t, err)
+}
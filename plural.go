@@ -0,0 +1,117 @@
+package aform
+
+import (
+	"github.com/go-playground/locales"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	pluralRegexp      = regexp.MustCompile(`\{([0-9A-Za-z_]+),\s*plural,`)
+	placeholderRegexp = regexp.MustCompile(`\{([0-9A-Za-z_]+)\}`)
+)
+
+// formatMessage replaces the positional parameters {0}, {1}, ... of message
+// by params. Plural blocks, see Messages, are resolved with the cardinal
+// plural rules of trans. Plural blocks that can't be parsed are left as is.
+func formatMessage(trans locales.Translator, message string, params []string) string {
+	return formatPlaceholders(trans, message, func(name string) (string, bool) {
+		index, err := strconv.Atoi(name)
+		if err != nil || index < 0 || index >= len(params) {
+			return "", false
+		}
+		return params[index], true
+	})
+}
+
+// formatPlaceholders replaces the placeholders of message, positional like
+// {0} or named like {label}, by the values returned by lookup. Plural blocks
+// are resolved like in formatMessage. Placeholders unknown by lookup are left
+// as is.
+func formatPlaceholders(trans locales.Translator, message string, lookup func(name string) (string, bool)) string {
+	var b strings.Builder
+	for {
+		loc := pluralRegexp.FindStringSubmatchIndex(message)
+		if loc == nil {
+			b.WriteString(message)
+			break
+		}
+		forms, length, ok := parsePluralForms(message[loc[1]:])
+		param, found := lookup(message[loc[2]:loc[3]])
+		if !ok || !found {
+			b.WriteString(message[:loc[1]])
+			message = message[loc[1]:]
+			continue
+		}
+		b.WriteString(message[:loc[0]])
+		b.WriteString(strings.ReplaceAll(selectPluralForm(trans, forms, param), "#", param))
+		message = message[loc[1]+length:]
+	}
+	return placeholderRegexp.ReplaceAllStringFunc(b.String(), func(placeholder string) string {
+		if value, ok := lookup(placeholder[1 : len(placeholder)-1]); ok {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// parsePluralForms parses the forms of a plural block up to its closing
+// brace. It returns the forms by selector and the length of the block parsed.
+func parsePluralForms(s string) (map[string]string, int, bool) {
+	forms := map[string]string{}
+	i := 0
+	for {
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		if i == len(s) {
+			return nil, 0, false
+		}
+		if s[i] == '}' {
+			return forms, i + 1, len(forms) > 0
+		}
+		start := i
+		for i < len(s) && s[i] != ' ' && s[i] != '{' && s[i] != '}' {
+			i++
+		}
+		selector := s[start:i]
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		if len(selector) == 0 || i == len(s) || s[i] != '{' {
+			return nil, 0, false
+		}
+		depth, textStart := 0, i+1
+		for ; i < len(s); i++ {
+			if s[i] == '{' {
+				depth++
+			} else if s[i] == '}' {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+		}
+		if i == len(s) {
+			return nil, 0, false
+		}
+		forms[selector] = s[textStart:i]
+		i++
+	}
+}
+
+// selectPluralForm returns the form of forms matching param.
+func selectPluralForm(trans locales.Translator, forms map[string]string, param string) string {
+	if form, ok := forms["="+param]; ok {
+		return form
+	}
+	if n, err := strconv.ParseFloat(param, 64); err == nil && trans != nil {
+		_, fraction, _ := strings.Cut(param, ".")
+		rule := trans.CardinalPluralRule(n, uint64(len(fraction)))
+		if form, ok := forms[strings.ToLower(rule.String())]; ok {
+			return form
+		}
+	}
+	return forms["other"]
+}
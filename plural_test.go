@@ -0,0 +1,188 @@
+package aform_test
+
+import (
+	"fmt"
+	"github.com/go-playground/locales/ru"
+	ru_translations "github.com/go-playground/validator/v10/translations/ru"
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"strconv"
+	"testing"
+)
+
+const filesErrorCode = "files"
+
+var filesMessages = aform.ErrorMessages{
+	language.English: "Attach {0, plural, =0 {no file} one {one file} other {# files}} to {1}",
+	language.French:  "Joignez {0, plural, =0 {aucun fichier} one {# fichier} other {# fichiers}} à {1}",
+	language.Russian: "Прикрепите {0, plural, one {# файл} few {# файла} many {# файлов} other {# файла}}",
+}
+
+// registerRussian registers the Russian language until the end of the test t.
+func registerRussian(t *testing.T) {
+	t.Helper()
+	messages := aform.Messages{
+		aform.MaxLengthErrorCode: "Максимальная длина: {0, plural, one {# символ} few {# символа} many {# символов} other {# символа}}",
+	}
+	if err := aform.RegisterLanguage(language.Russian, ru.New(), ru_translations.RegisterDefaultTranslations, messages); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		aform.ExportUnregisterLanguage(language.Russian)
+	})
+}
+
+func TestLengthError_plural(t *testing.T) {
+	registerRussian(t)
+	tests := []struct {
+		name      string
+		validator aform.Validator
+		value     string
+		locale    string
+		want      string
+	}{
+		{name: "min one en", validator: aform.MinLength(1), value: "", locale: "en", want: "Ensure this value has at least 1 character"},
+		{name: "max one en", validator: aform.MaxLength(1), value: "ab", locale: "en", want: "Ensure this value has at most 1 character"},
+		{name: "max zero en", validator: aform.MaxLength(0), value: "a", locale: "en", want: "Ensure this value has at most 0 characters"},
+		{name: "min one fr", validator: aform.MinLength(1), value: "", locale: "fr", want: "Assurez-vous que cette valeur fait au minimum 1 caractère"},
+		{name: "max zero fr", validator: aform.MaxLength(0), value: "a", locale: "fr", want: "Assurez-vous que cette valeur fait au maximum 0 caractère"},
+		{name: "max two fr", validator: aform.MaxLength(2), value: "abc", locale: "fr", want: "Assurez-vous que cette valeur fait au maximum 2 caractères"},
+		{name: "min one es", validator: aform.MinLength(1), value: "", locale: "es", want: "Asegúrese de que este valor tenga al menos 1 carácter"},
+		{name: "min one nl", validator: aform.MinLength(1), value: "", locale: "nl", want: "Zorg ervoor dat deze waarde minstens 1 teken bevat"},
+		{name: "max few ru", validator: aform.MaxLength(3), value: "abcd", locale: "ru", want: "Максимальная длина: 3 символа"},
+		{name: "max many ru", validator: aform.MaxLength(5), value: "abcdef", locale: "ru", want: "Максимальная длина: 5 символов"},
+		{name: "max one ru", validator: aform.MaxLength(21), value: "abcdefghijklmnopqrstuv", locale: "ru", want: "Максимальная длина: 21 символ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			err := tt.validator(tt.value)
+			if e, ok := err.(aform.Error); a.True(ok) {
+				a.Equal(tt.want, e.Translate(tt.locale))
+			}
+		})
+	}
+}
+
+func TestLengthError_pluralForm(t *testing.T) {
+	registerRussian(t)
+	tests := []struct {
+		name   string
+		locale string
+		max    uint
+		want   string
+	}{
+		{name: "en one", locale: "en", max: 1, want: "Ensure this value has at most 1 character"},
+		{name: "en other", locale: "en", max: 2, want: "Ensure this value has at most 2 characters"},
+		{name: "fr one", locale: "fr", max: 1, want: "Assurez-vous que cette valeur fait au maximum 1 caractère"},
+		{name: "it one", locale: "it", max: 1, want: "Assicurati che questo valore contenga al massimo 1 carattere"},
+		{name: "ru few", locale: "ru", max: 2, want: "Максимальная длина: 2 символа"},
+		{name: "ru many", locale: "ru", max: 11, want: "Максимальная длина: 11 символов"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			f := aform.Must(aform.New(
+				aform.WithLocales([]language.Tag{language.English, language.French, language.Italian, language.Russian}),
+				aform.WithCharField(aform.Must(aform.NewCharField("Code", "", "", 0, tt.max))),
+			))
+			f.BindData(map[string][]string{"code": {"abcdefghijkl"}}, tt.locale)
+			a.False(f.IsValid())
+			a.Equal(tt.want, f.Errors()["code"][0].Translate(tt.locale))
+		})
+	}
+}
+
+func TestNewError_plural(t *testing.T) {
+	registerRussian(t)
+	registerErrorCode(t, filesErrorCode, filesMessages)
+	tests := []struct {
+		name   string
+		params []string
+		locale string
+		want   string
+	}{
+		{name: "exact selector", params: []string{"0", "the offer"}, locale: "en", want: "Attach no file to the offer"},
+		{name: "one en", params: []string{"1", "the offer"}, locale: "en", want: "Attach one file to the offer"},
+		{name: "other en", params: []string{"3", "the offer"}, locale: "en", want: "Attach 3 files to the offer"},
+		{name: "fraction en", params: []string{"1.5", "the offer"}, locale: "en", want: "Attach 1.5 files to the offer"},
+		{name: "one fr", params: []string{"1", "l'offre"}, locale: "fr", want: "Joignez 1 fichier à l'offre"},
+		{name: "fraction fr", params: []string{"1.5", "l'offre"}, locale: "fr", want: "Joignez 1.5 fichier à l'offre"},
+		{name: "region fallback", params: []string{"2", "l'offre"}, locale: "fr-CA", want: "Joignez 2 fichiers à l'offre"},
+		{name: "few ru", params: []string{"22"}, locale: "ru", want: "Прикрепите 22 файла"},
+		{name: "many ru", params: []string{"12"}, locale: "ru", want: "Прикрепите 12 файлов"},
+		{name: "not a number", params: []string{"x", "the offer"}, locale: "en", want: "Attach x files to the offer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			a.Equal(tt.want, aform.NewError(filesErrorCode, tt.params...).Translate(tt.locale))
+		})
+	}
+}
+
+func TestNewError_pluralMalformed(t *testing.T) {
+	a := assert.New(t)
+	registerErrorCode(t, "malformed_plural", aform.ErrorMessages{
+		language.English: "Attach {0, plural, one {# file} other {# files} to {1}",
+	})
+	a.Equal("Attach {0, plural, one {# file} other {# files} to the offer", aform.NewError("malformed_plural", "2", "the offer").Translate("en"))
+}
+
+func TestFormSet_minAndMaxNum_plural(t *testing.T) {
+	tests := []struct {
+		name   string
+		opt    aform.FormSetOption
+		filled int
+		locale string
+		want   string
+	}{
+		{name: "min one en", opt: aform.WithMinNum(1), filled: 0, locale: "en", want: "Please submit at least 1 form"},
+		{name: "min two en", opt: aform.WithMinNum(2), filled: 1, locale: "en", want: "Please submit at least 2 forms"},
+		{name: "min one fr", opt: aform.WithMinNum(1), filled: 0, locale: "fr", want: "Veuillez soumettre au moins 1 formulaire"},
+		{name: "min one de", opt: aform.WithMinNum(1), filled: 0, locale: "de", want: "Bitte senden Sie mindestens 1 Formular ab"},
+		{name: "max one es", opt: aform.WithMaxNum(1), filled: 2, locale: "es", want: "Envíe como máximo 1 formulario"},
+		{name: "max one it", opt: aform.WithMaxNum(1), filled: 2, locale: "it", want: "Invia al massimo 1 modulo"},
+		{name: "max one pt", opt: aform.WithMaxNum(1), filled: 2, locale: "pt", want: "Submeta no máximo 1 formulário"},
+		{name: "max two nl", opt: aform.WithMaxNum(2), filled: 3, locale: "nl", want: "Verstuur maximaal 2 formulieren"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			fs := aform.Must(aform.NewFormSet(func(int) (*aform.Form, error) {
+				return aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("Company"))))
+			}, tt.opt))
+			data := map[string][]string{"form-total_forms": {strconv.Itoa(tt.filled + 1)}, "form-initial_forms": {"0"}}
+			for i := 0; i < tt.filled; i++ {
+				data[fmt.Sprintf("form-%d-company", i)] = []string{"Acme"}
+			}
+			fs.BindData(data)
+			a.False(fs.IsValid())
+			if errs := fs.NonFormErrors(); a.Len(errs, 1) {
+				a.Equal(tt.want, errs[0].Translate(tt.locale))
+			}
+		})
+	}
+}
+
+func TestFormSet_minNum_pluralMessages(t *testing.T) {
+	a := assert.New(t)
+	a.NoError(aform.RegisterMessages(language.English, aform.Messages{
+		aform.TooFewFormsErrorCode: "Add {0, plural, one {one experience} other {# experiences}}",
+	}))
+	t.Cleanup(func() {
+		aform.ExportDeleteMessages(language.English, aform.TooFewFormsErrorCode)
+	})
+	for min, want := range map[uint]string{1: "Add one experience", 3: "Add 3 experiences"} {
+		fs := aform.Must(aform.NewFormSet(func(int) (*aform.Form, error) {
+			return aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("Company"))))
+		}, aform.WithMinNum(min)))
+		fs.BindData(map[string][]string{"form-total_forms": {"0"}, "form-initial_forms": {"0"}})
+		a.False(fs.IsValid())
+		if errs := fs.NonFormErrors(); a.Len(errs, 1) {
+			a.Equal(want, errs[0].Translate("en"))
+			a.Equal(want, errs[0].Translate("en-GB"))
+		}
+	}
+}
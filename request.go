@@ -0,0 +1,67 @@
+package aform
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+)
+
+const (
+	// defaultMaxBodyBytes is the default maximum size of a request body read
+	// by BindRequest.
+	defaultMaxBodyBytes = int64(10 << 20)
+	// defaultMaxMemory is the default maximum size of a multipart body kept in
+	// memory by BindRequest. It's the same default as http.Request.FormValue.
+	defaultMaxMemory = int64(32 << 20)
+)
+
+// WithMaxBodyBytes returns a FormOption that limits the size of the request
+// body read by BindRequest. If the body is larger, BindRequest returns an
+// error. A value lower or equal to 0 removes the limit. Default is 10MB.
+func WithMaxBodyBytes(n int64) FormOption {
+	return func(f *Form) error {
+		f.maxBodyBytes = n
+		return nil
+	}
+}
+
+// WithMaxMemory returns a FormOption that sets the maximum size of a
+// multipart body stored in memory by BindRequest. The remaining parts are
+// stored on disk in temporary files. Default is 32MB.
+func WithMaxMemory(n int64) FormOption {
+	return func(f *Form) error {
+		if n <= 0 {
+			return fmt.Errorf("max memory must be greater than 0. Given: %d", n)
+		}
+		f.maxMemory = n
+		return nil
+	}
+}
+
+// parseRequest parses the URL query and the body of req. Body is parsed as
+// multipart/form-data or as application/x-www-form-urlencoded according to
+// the Content-Type header. If the body is larger than maxBodyBytes, it returns
+// an error. If req is already parsed, it does nothing.
+func parseRequest(req *http.Request, maxBodyBytes, maxMemory int64) error {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	isMultipart := mediaType == "multipart/form-data"
+	if isMultipart && req.MultipartForm != nil {
+		return nil
+	}
+	if !isMultipart && req.PostForm != nil {
+		return nil
+	}
+	if req.Body != nil && maxBodyBytes > 0 {
+		req.Body = http.MaxBytesReader(nil, req.Body, maxBodyBytes)
+	}
+	var err error
+	if isMultipart {
+		err = req.ParseMultipartForm(maxMemory)
+	} else {
+		err = req.ParseForm()
+	}
+	if err != nil {
+		return fmt.Errorf("fail to parse request: %w", err)
+	}
+	return nil
+}
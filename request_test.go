@@ -0,0 +1,67 @@
+package aform_test
+
+import (
+	"bytes"
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestForm_BindRequest_urlencoded(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=Jane"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	a.NoError(f.BindRequest(req))
+	a.True(f.IsBound())
+	a.True(f.IsValid())
+	a.Equal("Jane", f.CleanedData().Get("name"))
+}
+
+func TestForm_BindRequest_query(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+	a.NoError(f.BindRequest(httptest.NewRequest(http.MethodGet, "/?name=Jane", nil)))
+	a.Equal("Jane", f.CleanedData().Get("name"))
+}
+
+func TestForm_BindRequest_multipart(t *testing.T) {
+	a := assert.New(t)
+	body := &bytes.Buffer{}
+	mw := multipart.NewWriter(body)
+	a.NoError(mw.WriteField("name", "Jane"))
+	a.NoError(mw.Close())
+	f := aform.Must(aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+	req := httptest.NewRequest(http.MethodPost, "/", body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	a.NoError(f.BindRequest(req))
+	a.Equal("Jane", f.CleanedData().Get("name"))
+}
+
+func TestForm_BindRequest_bodyTooLarge(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithMaxBodyBytes(8), aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=Jane+Doe"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	a.Error(f.BindRequest(req))
+	a.False(f.IsBound())
+}
+
+func TestForm_BindRequest_invalidMultipart(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("not multipart"))
+	req.Header.Set("Content-Type", "multipart/form-data; boundary=xyz")
+	a.Error(f.BindRequest(req))
+	a.False(f.IsBound())
+}
+
+func TestWithMaxMemory_invalid(t *testing.T) {
+	a := assert.New(t)
+	_, err := aform.New(aform.WithMaxMemory(0))
+	a.EqualError(err, "max memory must be greater than 0. Given: 0")
+}
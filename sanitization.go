@@ -25,6 +25,15 @@ func removeNewlineAndTrimSpace(value string) string {
 	return trimSpace(reg.ReplaceAllString(value, " "))
 }
 
+// firstValue returns the first value of values or the empty string if
+// values is empty.
+func firstValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
 func trimSpace(value string) string {
 	return strings.TrimSpace(value)
 }
@@ -0,0 +1,159 @@
+package aform
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+)
+
+// SignatureErrorCode is the error code of the error returned when the value
+// of a field created with WithSigned or WithEncrypted has been modified.
+const SignatureErrorCode = "signature"
+
+// English and French error messages of a modified signed value.
+const (
+	SignatureErrorMessageEn = "This value has been modified"
+	SignatureErrorMessageFr = "Cette valeur a été modifiée"
+)
+
+// WithSigned returns a FieldOption that signs the value of the Field with
+// secret. The value is rendered followed by its HMAC-SHA256 signature. When
+// data is bound, the signature is checked and removed before validation. If
+// it doesn't match, the field gets an error with the code SignatureErrorCode.
+// The value is still readable by the user. To hide it, use WithEncrypted.
+// It is intended for fields rendered with HiddenInput, like IDs or prices
+// the user must not modify. The signature is bound to the prefixed name of
+// the Field: a value signed for another field is rejected. It returns an
+// error if the widget doesn't render the value, like CheckboxInput or the
+// choice widgets.
+func WithSigned(secret []byte) FieldOption {
+	return func(fld *Field) error {
+		if len(secret) == 0 {
+			return fmt.Errorf("signing secret can't be empty")
+		}
+		if err := checkProtectableWidget(fld); err != nil {
+			return err
+		}
+		fld.signingSecret = secret
+		return nil
+	}
+}
+
+// WithEncrypted returns a FieldOption that encrypts the value of the Field
+// with AES-GCM and a key derived from secret. The value never reaches the
+// client in plain text. Like with WithSigned, a modified value or a value
+// encrypted for another field gets an error with the code SignatureErrorCode,
+// and the widget must render the value.
+func WithEncrypted(secret []byte) FieldOption {
+	return func(fld *Field) error {
+		if len(secret) == 0 {
+			return fmt.Errorf("encryption secret can't be empty")
+		}
+		if err := checkProtectableWidget(fld); err != nil {
+			return err
+		}
+		key := sha256.Sum256(secret)
+		block, err := aes.NewCipher(key[:])
+		if err != nil {
+			return err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return err
+		}
+		fld.encryption = aead
+		return nil
+	}
+}
+
+// checkProtectableWidget returns an error if the widget of fld doesn't render
+// the bound value. The value submitted wouldn't be the protected value.
+func checkProtectableWidget(fld *Field) error {
+	if !fld.widget.isInput() || fld.widget.noAttrValue() {
+		return fmt.Errorf("%s field with widget %s can't be signed or encrypted", fld.name, fld.widget)
+	}
+	return nil
+}
+
+// signedFieldSigningPurpose is the purpose of the signature of the fields
+// created with WithSigned. See sign.
+const signedFieldSigningPurpose = "aform.signed:"
+
+// protectionPurpose returns the purpose of the signature of fld values, also
+// used as additional data of the encryption. It contains the prefixed name
+// of fld preceded by its length, so a purpose is never the prefix of another.
+func (fld *Field) protectionPurpose() string {
+	name := prefixedNameForField(fld)
+	return signedFieldSigningPurpose + strconv.Itoa(len(name)) + ":" + name + ":"
+}
+
+func (fld *Field) isProtected() bool {
+	return len(fld.signingSecret) > 0 || fld.encryption != nil
+}
+
+// protect returns value signed or encrypted for rendering.
+func (fld *Field) protect(value string) string {
+	if fld.encryption != nil {
+		nonce := make([]byte, fld.encryption.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			panic("protect: " + err.Error())
+		}
+		return base64.RawURLEncoding.EncodeToString(fld.encryption.Seal(nonce, nonce, []byte(value), []byte(fld.protectionPurpose())))
+	}
+	if len(fld.signingSecret) > 0 {
+		return sign(fld.signingSecret, fld.protectionPurpose(), value)
+	}
+	return value
+}
+
+// protectValues returns values protected like rendered values, ready to be
+// bound again.
+func protectValues(fld *Field, values []string) []string {
+	if !fld.isProtected() {
+		return values
+	}
+	output := make([]string, len(values))
+	for i, value := range values {
+		output[i] = fld.protect(value)
+	}
+	return output
+}
+
+// unprotect returns values verified and decrypted. It returns false if one
+// of them has been modified.
+func (fld *Field) unprotect(values []string) ([]string, bool) {
+	if !fld.isProtected() {
+		return values, true
+	}
+	output := make([]string, len(values))
+	for i, value := range values {
+		plain, err := fld.unprotectValue(value)
+		if err != nil {
+			return nil, false
+		}
+		output[i] = plain
+	}
+	return output, true
+}
+
+func (fld *Field) unprotectValue(value string) (string, error) {
+	if fld.encryption != nil {
+		b, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil || len(b) < fld.encryption.NonceSize() {
+			return "", ErrInvalidSignature
+		}
+		nonceSize := fld.encryption.NonceSize()
+		plain, err := fld.encryption.Open(nil, b[:nonceSize], b[nonceSize:], []byte(fld.protectionPurpose()))
+		if err != nil {
+			return "", ErrInvalidSignature
+		}
+		return string(plain), nil
+	}
+	return unsign(fld.signingSecret, fld.protectionPurpose(), value)
+}
+
+var signatureError = ErrorWrap(simpleError{code: SignatureErrorCode, fr: SignatureErrorMessageFr, en: SignatureErrorMessageEn})
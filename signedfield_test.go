@@ -0,0 +1,135 @@
+package aform_test
+
+import (
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+var signingSecret = []byte("signing secret")
+
+var hiddenValuePattern = regexp.MustCompile(`name="job_id" value="([^"]+)"`)
+
+// renderedJobID returns the value of the hidden field rendered by f.
+func renderedJobID(t *testing.T, f *aform.Form) string {
+	matches := hiddenValuePattern.FindStringSubmatch(string(f.AsDiv()))
+	if len(matches) != 2 {
+		t.Fatalf("hidden field not found in %s", f.AsDiv())
+	}
+	return matches[1]
+}
+
+func TestWithSigned(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(
+		aform.WithCharField(aform.Must(aform.NewCharField("Job ID", "1234", "", 0, 0, aform.WithWidget(aform.HiddenInput), aform.WithSigned(signingSecret)))),
+	))
+	rendered := renderedJobID(t, f)
+	fld, _ := f.FieldByName("Job ID")
+	a.Equal(aform.ExportSign(signingSecret, aform.ExportProtectionPurpose(fld), "1234"), rendered)
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{name: "rendered value", value: rendered, valid: true},
+		{name: "plain value", value: "1234"},
+		{name: "modified value", value: aform.ExportSign([]byte("other secret"), aform.ExportProtectionPurpose(fld), "9999")},
+		{name: "modified signature", value: "9999." + rendered[len("1234."):]},
+		{name: "signed without field", value: aform.ExportSign(signingSecret, "", "1234")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			f := aform.Must(aform.New(
+				aform.WithCharField(aform.Must(aform.NewCharField("Job ID", "1234", "", 0, 0, aform.WithWidget(aform.HiddenInput), aform.WithSigned(signingSecret)))),
+			))
+			f.BindData(map[string][]string{"job_id": {tt.value}})
+			a.Equal(tt.valid, f.IsValid())
+			if tt.valid {
+				a.Equal("1234", f.CleanedData().Get("job_id"))
+				a.Equal(rendered, renderedJobID(t, f))
+				return
+			}
+			a.False(f.CleanedData().Has("job_id"))
+			a.Equal(aform.SignatureErrorCode, f.Errors().Get("job_id").Code())
+			// A modified value is never rendered signed.
+			a.Equal(rendered, renderedJobID(t, f))
+		})
+	}
+}
+
+func TestWithEncrypted(t *testing.T) {
+	a := assert.New(t)
+	newForm := func(secret []byte) *aform.Form {
+		return aform.Must(aform.New(
+			aform.WithCharField(aform.Must(aform.NewCharField("Job ID", "1234", "", 0, 0, aform.WithWidget(aform.HiddenInput), aform.WithEncrypted(secret)))),
+		))
+	}
+	rendered := renderedJobID(t, newForm(signingSecret))
+	a.NotContains(rendered, "1234")
+	a.NotEqual(rendered, renderedJobID(t, newForm(signingSecret)))
+
+	f := newForm(signingSecret)
+	f.BindData(map[string][]string{"job_id": {rendered}})
+	a.True(f.IsValid())
+	a.Equal("1234", f.CleanedData().Get("job_id"))
+
+	for _, value := range []string{"1234", rendered[:len(rendered)-2], renderedJobID(t, newForm([]byte("other secret")))} {
+		f := newForm(signingSecret)
+		f.BindData(map[string][]string{"job_id": {value}})
+		a.False(f.IsValid())
+		a.Equal(aform.SignatureErrorCode, f.Errors().Get("job_id").Code())
+	}
+}
+
+func TestWithSigned_otherField(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  func() aform.FieldOption
+	}{
+		{name: "signed", opt: func() aform.FieldOption { return aform.WithSigned(signingSecret) }},
+		{name: "encrypted", opt: func() aform.FieldOption { return aform.WithEncrypted(signingSecret) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			f := aform.Must(aform.New(
+				aform.WithCharField(aform.Must(aform.NewCharField("Job ID", "1234", "", 0, 0, aform.WithWidget(aform.HiddenInput), tt.opt()))),
+				aform.WithCharField(aform.Must(aform.NewCharField("Price", "10", "", 0, 0, aform.WithWidget(aform.HiddenInput), tt.opt()))),
+			))
+			price := regexp.MustCompile(`name="price" value="([^"]+)"`).FindStringSubmatch(string(f.AsDiv()))[1]
+			f.BindData(map[string][]string{"job_id": {price}, "price": {price}})
+			a.False(f.IsValid())
+			a.Equal(aform.SignatureErrorCode, f.Errors().Get("job_id").Code())
+			a.Equal("10", f.CleanedData().Get("price"))
+			// The same field in a form with another prefix rejects it too.
+			f = aform.Must(aform.New(
+				aform.WithPrefix("other"),
+				aform.WithCharField(aform.Must(aform.NewCharField("Price", "10", "", 0, 0, aform.WithWidget(aform.HiddenInput), tt.opt()))),
+			))
+			f.BindData(map[string][]string{"other-price": {price}})
+			a.False(f.IsValid())
+			a.Equal(aform.SignatureErrorCode, f.Errors().Get("price").Code())
+		})
+	}
+}
+
+func TestWithSigned_widgetWithoutValue(t *testing.T) {
+	a := assert.New(t)
+	_, err := aform.DefaultBooleanField("Remote", aform.WithSigned(signingSecret))
+	a.EqualError(err, "Remote field with widget CheckboxInput can't be signed or encrypted")
+	_, err = aform.DefaultChoiceField("Contract", aform.WithEncrypted(signingSecret))
+	a.EqualError(err, "Contract field with widget Select can't be signed or encrypted")
+	_, err = aform.DefaultCharField("Notes", aform.WithWidget(aform.TextArea), aform.WithSigned(signingSecret))
+	a.NoError(err)
+}
+
+func TestWithSigned_emptySecret(t *testing.T) {
+	a := assert.New(t)
+	_, err := aform.DefaultCharField("Job ID", aform.WithSigned(nil))
+	a.Error(err)
+	_, err = aform.DefaultCharField("Job ID", aform.WithEncrypted(nil))
+	a.Error(err)
+}
@@ -0,0 +1,56 @@
+package aform
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidSignature is returned when a signed value doesn't match its
+// signature. It means the value has been modified or signed with another
+// secret.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+const signatureSeparator = "."
+
+// sign returns value followed by its HMAC-SHA256 signature computed with
+// secret for purpose. Signature is base64 URL encoded. Each feature signs
+// with its own purpose, so a value signed for one feature is not accepted by
+// another one, even if they share the same secret.
+func sign(secret []byte, purpose, value string) string {
+	return value + signatureSeparator + signature(secret, purpose, value)
+}
+
+// unsign returns the value signed with sign for purpose if the signature is
+// valid. Otherwise, it returns ErrInvalidSignature.
+func unsign(secret []byte, purpose, signed string) (string, error) {
+	i := strings.LastIndex(signed, signatureSeparator)
+	if i < 0 {
+		return "", ErrInvalidSignature
+	}
+	value, sig := signed[:i], signed[i+len(signatureSeparator):]
+	if !hmac.Equal([]byte(sig), []byte(signature(secret, purpose, value))) {
+		return "", ErrInvalidSignature
+	}
+	return value, nil
+}
+
+// signature returns the HMAC-SHA256 signature of purpose followed by value.
+func signature(secret []byte, purpose, value string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(purpose + value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// randomToken returns a random hexadecimal string built from n random bytes.
+func randomToken(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic("randomToken: " + err.Error())
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,130 @@
+package aform
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Error codes of the strict binding validations. See WithStrictBinding.
+const (
+	UnknownFieldErrorCode   = "unknown_field"
+	MultipleValuesErrorCode = "multiple_values"
+	TooManyValuesErrorCode  = "too_many_values"
+)
+
+// English error messages of the strict binding validations. {0} is replaced
+// by the name of the field and {1} by the maximum number of values.
+const (
+	UnknownFieldErrorMessageEn   = "Unexpected field {0}"
+	MultipleValuesErrorMessageEn = "Field {0} accepts a single value"
+	TooManyValuesErrorMessageEn  = "Field {0} accepts at most {1} values"
+)
+
+// French error messages of the strict binding validations. {0} is replaced
+// by the name of the field and {1} by the maximum number of values.
+const (
+	UnknownFieldErrorMessageFr   = "Champ inattendu {0}"
+	MultipleValuesErrorMessageFr = "Le champ {0} accepte une seule valeur"
+	TooManyValuesErrorMessageFr  = "Le champ {0} accepte au maximum {1} valeurs"
+)
+
+// WithStrictBinding returns a FormOption that activates the strict binding
+// mode. By default, BindData silently ignores data keys not matching a field
+// and single value fields keep only the first value. In strict mode, the
+// following cases add errors not attached to a field (see
+// Form.NonFieldErrors) and the form is not valid:
+//   - a data key doesn't match any field (UnknownFieldErrorCode)
+//   - several values are bound to a single value field
+//     (MultipleValuesErrorCode)
+//   - more values than the maximum set with WithMaxValues are bound to a
+//     field (TooManyValuesErrorCode)
+//
+// If the form has a prefix, only the keys starting with the prefix are
+// checked. It allows several forms to be bound with the same data. When the
+// form is bound with BindRequest, only the keys of the request body are
+// checked: URL query parameters, such as the one read by QueryLocaleResolver,
+// are ignored. The keys of the management fields of a Wizard or a FormSet
+// the form belongs to are ignored as well.
+func WithStrictBinding() FormOption {
+	return func(f *Form) error {
+		f.strict = true
+		return nil
+	}
+}
+
+// WithMaxValues returns a FormOption that sets the maximum number of values
+// bound to a field in strict binding mode. 0 means no maximum. It is useful
+// to limit the number of values of a MultipleChoiceField. See
+// WithStrictBinding.
+func WithMaxValues(n uint) FormOption {
+	return func(f *Form) error {
+		f.maxValues = n
+		return nil
+	}
+}
+
+func (f *Form) strictBindingErrors(data map[string][]string) []Error {
+	var errs []Error
+	known := map[string]fieldInterface{}
+	for _, fld := range f.fields {
+		known[prefixedNameForField(fld)] = fld
+	}
+	for _, name := range f.protectionFieldNames() {
+		known[name] = nil
+	}
+	for _, name := range f.managementKeys {
+		known[name] = nil
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if len(f.prefix) > 0 && !strings.HasPrefix(key, f.prefix+"-") {
+			continue
+		}
+		fld, ok := known[key]
+		if !ok {
+			errs = append(errs, strictBindingError(UnknownFieldErrorCode, UnknownFieldErrorMessageEn, UnknownFieldErrorMessageFr, key, 0))
+			continue
+		}
+		if fld == nil {
+			continue
+		}
+		values := data[key]
+		if fld.Type() != MultipleChoiceFieldType && fld.Type() != CaptchaFieldType && len(values) > 1 {
+			errs = append(errs, strictBindingError(MultipleValuesErrorCode, MultipleValuesErrorMessageEn, MultipleValuesErrorMessageFr, key, 0))
+			continue
+		}
+		if f.maxValues > 0 && uint(len(values)) > f.maxValues {
+			errs = append(errs, strictBindingError(TooManyValuesErrorCode, TooManyValuesErrorMessageEn, TooManyValuesErrorMessageFr, key, f.maxValues))
+		}
+	}
+	return errs
+}
+
+func strictBindingError(code, en, fr, name string, max uint) Error {
+	params := []string{name, strconv.FormatUint(uint64(max), 10)}
+	return ErrorWrap(simpleError{code: code, fr: fr, en: en, params: params})
+}
+
+// protectionFieldNames returns the names of the inputs rendered by AsDiv
+// that are not fields: CSRF token, timestamp, one-time token and honeypot.
+func (f *Form) protectionFieldNames() []string {
+	var names []string
+	if f.csrfEnabled() {
+		names = append(names, f.csrfFieldName())
+	}
+	if f.timestampEnabled() {
+		names = append(names, f.timestampFieldName())
+	}
+	if f.oneTimeTokenEnabled() {
+		names = append(names, f.oneTimeTokenFieldName())
+	}
+	if f.honeypotEnabled() {
+		names = append(names, f.honeypotFieldName())
+	}
+	return names
+}
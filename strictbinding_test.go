@@ -0,0 +1,126 @@
+package aform_test
+
+import (
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestWithStrictBinding(t *testing.T) {
+	newForm := func(opts ...aform.FormOption) *aform.Form {
+		opts = append(opts,
+			aform.WithStrictBinding(),
+			aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))),
+			aform.WithMultipleChoiceField(aform.Must(aform.DefaultMultipleChoiceField("Skills", aform.WithChoiceOptions([]aform.ChoiceFieldOption{{Value: "go"}, {Value: "sql"}, {Value: "js"}})))),
+		)
+		return aform.Must(aform.New(opts...))
+	}
+	tests := []struct {
+		name      string
+		form      *aform.Form
+		data      map[string][]string
+		wantCodes []string
+		wantEn    []string
+	}{
+		{
+			name: "valid",
+			form: newForm(),
+			data: map[string][]string{"name": {"Jane"}, "skills": {"go", "sql"}},
+		},
+		{
+			name:      "unknown fields",
+			form:      newForm(),
+			data:      map[string][]string{"name": {"Jane"}, "skills": {"go"}, "is_admin": {"on"}, "debug": {"1"}},
+			wantCodes: []string{aform.UnknownFieldErrorCode, aform.UnknownFieldErrorCode},
+			wantEn:    []string{"Unexpected field debug", "Unexpected field is_admin"},
+		},
+		{
+			name:      "multiple values for a single value field",
+			form:      newForm(),
+			data:      map[string][]string{"name": {"Jane", "John"}, "skills": {"go"}},
+			wantCodes: []string{aform.MultipleValuesErrorCode},
+			wantEn:    []string{"Field name accepts a single value"},
+		},
+		{
+			name:      "too many values",
+			form:      newForm(aform.WithMaxValues(2)),
+			data:      map[string][]string{"name": {"Jane"}, "skills": {"go", "sql", "js"}},
+			wantCodes: []string{aform.TooManyValuesErrorCode},
+			wantEn:    []string{"Field skills accepts at most 2 values"},
+		},
+		{
+			name: "keys without the prefix are ignored",
+			form: newForm(aform.WithPrefix("p")),
+			data: map[string][]string{"p-name": {"Jane"}, "p-skills": {"go"}, "other": {"x"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			tt.form.BindData(tt.data)
+			a.Equal(len(tt.wantCodes) == 0, tt.form.IsValid())
+			errs := tt.form.NonFieldErrors()
+			a.Len(errs, len(tt.wantCodes))
+			for i, err := range errs {
+				a.Equal(tt.wantCodes[i], err.Code())
+				a.Equal(tt.wantEn[i], err.Error())
+			}
+		})
+	}
+}
+
+func TestWithStrictBinding_notStrict(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+	f.BindData(map[string][]string{"name": {"Jane", "John"}, "is_admin": {"on"}})
+	a.True(f.IsValid())
+	a.Empty(f.NonFieldErrors())
+}
+
+func TestWithStrictBinding_AsDiv(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithStrictBinding(), aform.WithLocales([]language.Tag{language.English, language.French}), aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+	f.BindData(map[string][]string{"name": {"Jane"}, "is_admin": {"on"}}, "fr")
+	a.False(f.IsValid())
+	expected := `
+<ul class="errorlist nonfield"><li>Champ inattendu is_admin</li></ul>
+<div><label for="id_name">Name</label><input type="text" name="name" value="Jane" id="id_name" maxlength="256" required></div>`
+	a.Equal(expected, string(f.AsDiv()))
+}
+
+func TestWithStrictBinding_BindRequest_ignoresQuery(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithStrictBinding(), aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+	req := httptest.NewRequest(http.MethodPost, "/?lang=fr", strings.NewReader("name=Jane&debug=1"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	a.NoError(f.BindRequest(req))
+	a.False(f.IsValid())
+	errs := f.NonFieldErrors()
+	a.Len(errs, 1)
+	a.Equal("Unexpected field debug", errs[0].Translate("en"))
+}
+
+func TestWithStrictBinding_wizardStep(t *testing.T) {
+	a := assert.New(t)
+	newWizard := func() *aform.Wizard {
+		return aform.Must(aform.NewWizard([]*aform.Form{
+			aform.Must(aform.New(aform.WithStrictBinding(), aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))))),
+			aform.Must(aform.New(aform.WithStrictBinding(), aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))))),
+		}, aform.NewMemoryWizardStore("wizard", 0)))
+	}
+	for _, values := range []url.Values{
+		{"wizard-current_step": {"0"}, "name": {"Jane"}},
+		{"wizard-current_step": {"0"}, "wizard-goto_step": {"0"}, "name": {"Jane"}},
+	} {
+		wz := newWizard()
+		done, err := wz.Process(httptest.NewRecorder(), testWizardRequest(values, nil))
+		a.NoError(err)
+		a.False(done)
+		a.Equal(1, wz.CurrentStep())
+	}
+}
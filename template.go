@@ -96,6 +96,17 @@ type tmplError struct {
 	Attrs tmplAttrs
 }
 
+type tmplErrorSummary struct {
+	Title tmplError
+	List  []tmplSummaryItem
+	Attrs tmplAttrs
+}
+
+type tmplSummaryItem struct {
+	Text string
+	Href string
+}
+
 type tmplHelpText struct {
 	Text  template.HTML
 	Attrs tmplAttrs
@@ -139,13 +150,36 @@ var formTemplateDefinitions = []map[string]string{
 	{"field_as_div": `<div{{ with .CSSClasses }} class="{{.}}"{{end}}>{{if .UseFieldset}}
 <fieldset>{{ .LegendTag }}
 {{if .HasErrors}}{{ .Errors }}
+{{end}}{{if .HasWarnings}}{{ .Warnings }}
 {{end}}{{else}}{{ .LabelTag }}{{if .HasErrors}}
-{{ .Errors }}
+{{ .Errors }}{{end}}{{if .HasWarnings}}
+{{ .Warnings }}{{end}}{{if or .HasErrors .HasWarnings}}
 {{end}}{{end}}{{ .Widget }}{{if .HasHelpText}}
 {{.HelpText}}{{end}}{{if .UseFieldset}}
 </fieldset>
 {{end}}</div>`},
-	{"form_as_div": `{{- range .Form.Fields}}
+	{"error_summary": `<div{{ template "attrs" .Summary }}>
+<h2{{ template "attrs" .Summary.Title }}>{{ .Summary.Title.Text }}</h2>
+<ul class="error-summary-list">{{ range $item := .Summary.List }}
+<li>{{ with $item.Href }}<a href="{{ . }}">{{ $item.Text }}</a>{{ else }}{{ $item.Text }}{{ end }}</li>{{ end }}
+</ul>
+</div>`},
+	{"form_as_div": `{{- with .NonFieldErrors}}
+{{ template "errors" . }}
+{{- end}}
+{{- with .CSRF}}
+{{ template "input" . }}
+{{- end}}
+{{- with .Timestamp}}
+{{ template "input" . }}
+{{- end}}
+{{- with .OneTimeToken}}
+{{ template "input" . }}
+{{- end}}
+{{- with .Honeypot}}
+<div style="display:none" aria-hidden="true">{{ template "input" . }}</div>
+{{- end}}
+{{- range .Form.Fields}}
 {{ .AsDiv }}
 {{- end}}`},
 }
@@ -161,7 +195,23 @@ func mustFormAsDivTemplate(f *Form) template.HTML {
 func formAsDivTemplate(f *Form) (template.HTML, error) {
 	t := loadTemplates()
 	buf := &bytes.Buffer{}
-	err := t.ExecuteTemplate(buf, "form_as_div", map[string]interface{}{"Form": f})
+	data := map[string]interface{}{"Form": f}
+	if nonFieldErrors := f.nonFieldErrorsForTemplate(); nonFieldErrors != nil {
+		data["NonFieldErrors"] = map[string]interface{}{"Errors": nonFieldErrors}
+	}
+	if f.csrfEnabled() {
+		data["CSRF"] = widgetInput{Type: HiddenInput, Name: f.csrfFieldName(), Value: f.csrfValue(), Attrs: tmplAttrs{}}
+	}
+	if f.timestampEnabled() {
+		data["Timestamp"] = widgetInput{Type: HiddenInput, Name: f.timestampFieldName(), Value: f.timestampValue(), Attrs: tmplAttrs{}}
+	}
+	if f.oneTimeTokenEnabled() {
+		data["OneTimeToken"] = widgetInput{Type: HiddenInput, Name: f.oneTimeTokenFieldName(), Value: f.oneTimeTokenValue(), Attrs: tmplAttrs{}}
+	}
+	if f.honeypotEnabled() {
+		data["Honeypot"] = widgetInput{Type: TextInput, Name: f.honeypotFieldName(), Attrs: tmplAttrs{"tabindex": "-1", "autocomplete": "off"}}
+	}
+	err := t.ExecuteTemplate(buf, "form_as_div", data)
 	if err != nil {
 		return "", err
 	}
@@ -274,6 +324,24 @@ func errorsTemplate(errors *tmplErrors) (template.HTML, error) {
 	return template.HTML(buf.String()), nil
 }
 
+func mustErrorSummaryTemplate(summary *tmplErrorSummary) template.HTML {
+	tmpl, err := errorSummaryTemplate(summary)
+	if err != nil {
+		panic(fmt.Sprintf("mustErrorSummaryTemplate: %s", err.Error()))
+	}
+	return tmpl
+}
+
+func errorSummaryTemplate(summary *tmplErrorSummary) (template.HTML, error) {
+	t := loadTemplates()
+	buf := &bytes.Buffer{}
+	err := t.ExecuteTemplate(buf, "error_summary", map[string]interface{}{"Summary": summary})
+	if err != nil {
+		return "", err
+	}
+	return template.HTML(buf.String()), nil
+}
+
 func mustHelpTextTemplate(helpText *tmplHelpText) template.HTML {
 	tmpl, err := helpTextTemplate(helpText)
 	if err != nil {
@@ -0,0 +1,92 @@
+package aform
+
+import (
+	"sync"
+	"time"
+)
+
+const defaultTokenTTL = time.Hour
+
+// TokenStore defines the interface used by a Form created with
+// WithOneTimeToken to issue and consume one-time submission tokens.
+type TokenStore interface {
+	// Issue generates and saves a new token.
+	Issue() (string, error)
+	// Consume marks token as used. It returns true the first time a token
+	// issued by the store is consumed, and false if the token is unknown,
+	// expired or already consumed.
+	Consume(token string) (bool, error)
+}
+
+// verify interface compliance
+var _ TokenStore = (*MemoryTokenStore)(nil)
+
+// MemoryTokenStore is a TokenStore keeping tokens in memory. Tokens are lost
+// when the application restarts, and they are not shared between several
+// instances of the application. It is safe for concurrent use.
+type MemoryTokenStore struct {
+	ttl    time.Duration
+	mu     sync.Mutex
+	tokens map[string]time.Time
+	// queue lists issued tokens by expiry from index head. All tokens have
+	// the same ttl, so they expire in the order they are issued.
+	queue []issuedToken
+	head  int
+}
+
+type issuedToken struct {
+	token  string
+	expiry time.Time
+}
+
+// NewMemoryTokenStore returns a MemoryTokenStore. Tokens not consumed expire
+// after ttl. If ttl is 0, they expire after one hour.
+func NewMemoryTokenStore(ttl time.Duration) *MemoryTokenStore {
+	if ttl <= 0 {
+		ttl = defaultTokenTTL
+	}
+	return &MemoryTokenStore{
+		ttl:    ttl,
+		tokens: map[string]time.Time{},
+	}
+}
+
+// Issue generates a random token. Expired tokens are removed.
+func (s *MemoryTokenStore) Issue() (string, error) {
+	token := randomToken(16)
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeExpired(now)
+	expiry := now.Add(s.ttl)
+	s.tokens[token] = expiry
+	s.queue = append(s.queue, issuedToken{token: token, expiry: expiry})
+	return token, nil
+}
+
+// removeExpired removes the tokens expired at now. Only the expired tokens at
+// the front of the queue are visited. The queue is compacted when more than
+// half of it has been removed.
+func (s *MemoryTokenStore) removeExpired(now time.Time) {
+	for ; s.head < len(s.queue) && now.After(s.queue[s.head].expiry); s.head++ {
+		delete(s.tokens, s.queue[s.head].token)
+		s.queue[s.head] = issuedToken{}
+	}
+	if s.head > len(s.queue)/2 {
+		s.queue = append([]issuedToken(nil), s.queue[s.head:]...)
+		s.head = 0
+	}
+}
+
+// Consume removes token. It returns true if token was issued and not
+// expired.
+func (s *MemoryTokenStore) Consume(token string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expiry, ok := s.tokens[token]
+	if !ok {
+		return false, nil
+	}
+	delete(s.tokens, token)
+	return !time.Now().After(expiry), nil
+}
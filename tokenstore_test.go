@@ -0,0 +1,57 @@
+package aform_test
+
+import (
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMemoryTokenStore(t *testing.T) {
+	a := assert.New(t)
+	store := aform.NewMemoryTokenStore(0)
+	token, err := store.Issue()
+	a.NoError(err)
+	other, err := store.Issue()
+	a.NoError(err)
+	a.NotEqual(token, other)
+	ok, err := store.Consume(token)
+	a.NoError(err)
+	a.True(ok)
+	ok, err = store.Consume(token)
+	a.NoError(err)
+	a.False(ok)
+	ok, err = store.Consume("unknown")
+	a.NoError(err)
+	a.False(ok)
+	ok, err = store.Consume(other)
+	a.NoError(err)
+	a.True(ok)
+}
+
+func TestMemoryTokenStore_expired(t *testing.T) {
+	a := assert.New(t)
+	store := aform.NewMemoryTokenStore(time.Nanosecond)
+	token, err := store.Issue()
+	a.NoError(err)
+	time.Sleep(time.Millisecond)
+	ok, err := store.Consume(token)
+	a.NoError(err)
+	a.False(ok)
+}
+
+func TestMemoryTokenStore_expiredRemovedOnIssue(t *testing.T) {
+	a := assert.New(t)
+	store := aform.NewMemoryTokenStore(50 * time.Millisecond)
+	for i := 0; i < 10; i++ {
+		_, err := store.Issue()
+		a.NoError(err)
+	}
+	time.Sleep(60 * time.Millisecond)
+	token, err := store.Issue()
+	a.NoError(err)
+	a.Equal(1, aform.ExportMemoryTokenStoreLen(store))
+	ok, err := store.Consume(token)
+	a.NoError(err)
+	a.True(ok)
+}
@@ -2,42 +2,258 @@ package aform
 
 import (
 	"fmt"
+	german "github.com/go-playground/locales/de"
 	english "github.com/go-playground/locales/en"
+	spanish "github.com/go-playground/locales/es"
 	french "github.com/go-playground/locales/fr"
+	italian "github.com/go-playground/locales/it"
+	dutch "github.com/go-playground/locales/nl"
+	portuguese "github.com/go-playground/locales/pt"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	en_translations "github.com/go-playground/validator/v10/translations/en"
+	es_translations "github.com/go-playground/validator/v10/translations/es"
 	fr_translations "github.com/go-playground/validator/v10/translations/fr"
-	"golang.org/x/exp/slices"
+	it_translations "github.com/go-playground/validator/v10/translations/it"
+	nl_translations "github.com/go-playground/validator/v10/translations/nl"
+	pt_translations "github.com/go-playground/validator/v10/translations/pt"
 	"golang.org/x/text/language"
 )
 
-// English error messages of the available validations.
+// English error messages of the available validations. The length messages
+// select the plural form of {0}, see Messages.
 const (
 	BooleanErrorMessageEn   = "Enter a valid boolean"
 	EmailErrorMessageEn     = "Enter a valid email address"
 	ChoiceErrorMessageEn    = "Invalid choice"
-	MinLengthErrorMessageEn = "Ensure this value has at least {0} characters"
-	MaxLengthErrorMessageEn = "Ensure this value has at most {0} characters"
+	MinLengthErrorMessageEn = "Ensure this value has at least {0, plural, one {# character} other {# characters}}"
+	MaxLengthErrorMessageEn = "Ensure this value has at most {0, plural, one {# character} other {# characters}}"
 	RequiredErrorMessageEn  = "This field is required"
 	URLErrorMessageEn       = "Enter a valid URL"
 )
 
-// French error messages of the available validations.
+// English error messages of the validations involving several fields. {0} is
+// replaced by the labels of the fields.
+const (
+	FieldsEqualErrorMessageEn       = "Ensure this value matches {0}"
+	AtLeastOneOfErrorMessageEn      = "Fill in at least one of these fields: {0}"
+	MutuallyExclusiveErrorMessageEn = "Fill in only one of these fields: {0}"
+)
+
+// English error messages of the built-in validators.
+const (
+	RegexErrorMessageEn   = "Enter a valid value"
+	NotInErrorMessageEn   = "This value is not allowed"
+	IntegerErrorMessageEn = "Enter a whole number"
+)
+
+// French error messages of the available validations. The length messages
+// select the plural form of {0}, see Messages.
 const (
 	BooleanErrorMessageFr   = "Entrez un booléen valide"
 	EmailErrorMessageFr     = "Entrez une adresse e-mail valide"
 	ChoiceErrorMessageFr    = "Choix invalide"
-	MinLengthErrorMessageFr = "Assurez-vous que cette valeur fait au minimum {0} caractères"
-	MaxLengthErrorMessageFr = "Assurez-vous que cette valeur fait au maximum {0} caractères"
+	MinLengthErrorMessageFr = "Assurez-vous que cette valeur fait au minimum {0, plural, one {# caractère} other {# caractères}}"
+	MaxLengthErrorMessageFr = "Assurez-vous que cette valeur fait au maximum {0, plural, one {# caractère} other {# caractères}}"
 	RequiredErrorMessageFr  = "Ce champ est obligatoire"
 	URLErrorMessageFr       = "Entrez une URL valide"
 )
 
+// French error messages of the validations involving several fields. {0} is
+// replaced by the labels of the fields.
+const (
+	FieldsEqualErrorMessageFr       = "Assurez-vous que cette valeur correspond à {0}"
+	AtLeastOneOfErrorMessageFr      = "Remplissez au moins un de ces champs : {0}"
+	MutuallyExclusiveErrorMessageFr = "Remplissez un seul de ces champs : {0}"
+)
+
+// French error messages of the built-in validators.
+const (
+	RegexErrorMessageFr   = "Entrez une valeur valide"
+	NotInErrorMessageFr   = "Cette valeur n'est pas autorisée"
+	IntegerErrorMessageFr = "Entrez un nombre entier"
+)
+
+// German error messages of the available validations.
+const (
+	BooleanErrorMessageDe   = "Geben Sie einen gültigen booleschen Wert ein"
+	EmailErrorMessageDe     = "Geben Sie eine gültige E-Mail-Adresse ein"
+	ChoiceErrorMessageDe    = "Ungültige Auswahl"
+	MinLengthErrorMessageDe = "Stellen Sie sicher, dass dieser Wert mindestens {0} Zeichen hat"
+	MaxLengthErrorMessageDe = "Stellen Sie sicher, dass dieser Wert höchstens {0} Zeichen hat"
+	RequiredErrorMessageDe  = "Dieses Feld ist erforderlich"
+	URLErrorMessageDe       = "Geben Sie eine gültige URL ein"
+)
+
+// Spanish error messages of the available validations.
+const (
+	BooleanErrorMessageEs   = "Introduzca un valor booleano válido"
+	EmailErrorMessageEs     = "Introduzca una dirección de correo electrónico válida"
+	ChoiceErrorMessageEs    = "Opción no válida"
+	MinLengthErrorMessageEs = "Asegúrese de que este valor tenga al menos {0, plural, one {# carácter} other {# caracteres}}"
+	MaxLengthErrorMessageEs = "Asegúrese de que este valor tenga como máximo {0, plural, one {# carácter} other {# caracteres}}"
+	RequiredErrorMessageEs  = "Este campo es obligatorio"
+	URLErrorMessageEs       = "Introduzca una URL válida"
+)
+
+// Italian error messages of the available validations.
+const (
+	BooleanErrorMessageIt   = "Inserisci un valore booleano valido"
+	EmailErrorMessageIt     = "Inserisci un indirizzo email valido"
+	ChoiceErrorMessageIt    = "Scelta non valida"
+	MinLengthErrorMessageIt = "Assicurati che questo valore contenga almeno {0, plural, one {# carattere} other {# caratteri}}"
+	MaxLengthErrorMessageIt = "Assicurati che questo valore contenga al massimo {0, plural, one {# carattere} other {# caratteri}}"
+	RequiredErrorMessageIt  = "Questo campo è obbligatorio"
+	URLErrorMessageIt       = "Inserisci un URL valido"
+)
+
+// Portuguese error messages of the available validations.
+const (
+	BooleanErrorMessagePt   = "Introduza um valor booleano válido"
+	EmailErrorMessagePt     = "Introduza um endereço de e-mail válido"
+	ChoiceErrorMessagePt    = "Escolha inválida"
+	MinLengthErrorMessagePt = "Certifique-se de que este valor tem pelo menos {0, plural, one {# caractere} other {# caracteres}}"
+	MaxLengthErrorMessagePt = "Certifique-se de que este valor tem no máximo {0, plural, one {# caractere} other {# caracteres}}"
+	RequiredErrorMessagePt  = "Este campo é obrigatório"
+	URLErrorMessagePt       = "Introduza um URL válido"
+)
+
+// Dutch error messages of the available validations.
+const (
+	BooleanErrorMessageNl   = "Voer een geldige booleaanse waarde in"
+	EmailErrorMessageNl     = "Voer een geldig e-mailadres in"
+	ChoiceErrorMessageNl    = "Ongeldige keuze"
+	MinLengthErrorMessageNl = "Zorg ervoor dat deze waarde minstens {0, plural, one {# teken} other {# tekens}} bevat"
+	MaxLengthErrorMessageNl = "Zorg ervoor dat deze waarde hoogstens {0, plural, one {# teken} other {# tekens}} bevat"
+	RequiredErrorMessageNl  = "Dit veld is verplicht"
+	URLErrorMessageNl       = "Voer een geldige URL in"
+)
+
+var (
+	languages = []language.Tag{language.English, language.French, language.German, language.Spanish, language.Italian, language.Portuguese, language.Dutch}
+)
+
+// Messages of the bundled languages other than English. Messages missing,
+// like the ones of the validations involving several fields, fall back to
+// English.
+var (
+	validatorErrorMessagesFr = Messages{
+		BooleanErrorCode:   BooleanErrorMessageFr,
+		EmailErrorCode:     EmailErrorMessageFr,
+		ChoiceErrorCode:    ChoiceErrorMessageFr,
+		MinLengthErrorCode: MinLengthErrorMessageFr,
+		MaxLengthErrorCode: MaxLengthErrorMessageFr,
+		RequiredErrorCode:  RequiredErrorMessageFr,
+		URLErrorCode:       URLErrorMessageFr,
+	}
+	validatorErrorMessagesDe = Messages{
+		BooleanErrorCode:   BooleanErrorMessageDe,
+		EmailErrorCode:     EmailErrorMessageDe,
+		ChoiceErrorCode:    ChoiceErrorMessageDe,
+		MinLengthErrorCode: MinLengthErrorMessageDe,
+		MaxLengthErrorCode: MaxLengthErrorMessageDe,
+		RequiredErrorCode:  RequiredErrorMessageDe,
+		URLErrorCode:       URLErrorMessageDe,
+	}
+	validatorErrorMessagesEs = Messages{
+		BooleanErrorCode:   BooleanErrorMessageEs,
+		EmailErrorCode:     EmailErrorMessageEs,
+		ChoiceErrorCode:    ChoiceErrorMessageEs,
+		MinLengthErrorCode: MinLengthErrorMessageEs,
+		MaxLengthErrorCode: MaxLengthErrorMessageEs,
+		RequiredErrorCode:  RequiredErrorMessageEs,
+		URLErrorCode:       URLErrorMessageEs,
+	}
+	validatorErrorMessagesIt = Messages{
+		BooleanErrorCode:   BooleanErrorMessageIt,
+		EmailErrorCode:     EmailErrorMessageIt,
+		ChoiceErrorCode:    ChoiceErrorMessageIt,
+		MinLengthErrorCode: MinLengthErrorMessageIt,
+		MaxLengthErrorCode: MaxLengthErrorMessageIt,
+		RequiredErrorCode:  RequiredErrorMessageIt,
+		URLErrorCode:       URLErrorMessageIt,
+	}
+	validatorErrorMessagesPt = Messages{
+		BooleanErrorCode:   BooleanErrorMessagePt,
+		EmailErrorCode:     EmailErrorMessagePt,
+		ChoiceErrorCode:    ChoiceErrorMessagePt,
+		MinLengthErrorCode: MinLengthErrorMessagePt,
+		MaxLengthErrorCode: MaxLengthErrorMessagePt,
+		RequiredErrorCode:  RequiredErrorMessagePt,
+		URLErrorCode:       URLErrorMessagePt,
+	}
+	validatorErrorMessagesNl = Messages{
+		BooleanErrorCode:   BooleanErrorMessageNl,
+		EmailErrorCode:     EmailErrorMessageNl,
+		ChoiceErrorCode:    ChoiceErrorMessageNl,
+		MinLengthErrorCode: MinLengthErrorMessageNl,
+		MaxLengthErrorCode: MaxLengthErrorMessageNl,
+		RequiredErrorCode:  RequiredErrorMessageNl,
+		URLErrorCode:       URLErrorMessageNl,
+	}
+)
+
+// Messages of the FormSet validations in the bundled languages other than
+// English and French.
 var (
-	languages = []language.Tag{language.English, language.French}
+	formSetErrorMessagesDe = Messages{
+		ManagementFormErrorCode: ManagementFormErrorMessageDe,
+		TooFewFormsErrorCode:    TooFewFormsErrorMessageDe,
+		TooManyFormsErrorCode:   TooManyFormsErrorMessageDe,
+		OrderErrorCode:          OrderErrorMessageDe,
+	}
+	formSetErrorMessagesEs = Messages{
+		ManagementFormErrorCode: ManagementFormErrorMessageEs,
+		TooFewFormsErrorCode:    TooFewFormsErrorMessageEs,
+		TooManyFormsErrorCode:   TooManyFormsErrorMessageEs,
+		OrderErrorCode:          OrderErrorMessageEs,
+	}
+	formSetErrorMessagesIt = Messages{
+		ManagementFormErrorCode: ManagementFormErrorMessageIt,
+		TooFewFormsErrorCode:    TooFewFormsErrorMessageIt,
+		TooManyFormsErrorCode:   TooManyFormsErrorMessageIt,
+		OrderErrorCode:          OrderErrorMessageIt,
+	}
+	formSetErrorMessagesPt = Messages{
+		ManagementFormErrorCode: ManagementFormErrorMessagePt,
+		TooFewFormsErrorCode:    TooFewFormsErrorMessagePt,
+		TooManyFormsErrorCode:   TooManyFormsErrorMessagePt,
+		OrderErrorCode:          OrderErrorMessagePt,
+	}
+	formSetErrorMessagesNl = Messages{
+		ManagementFormErrorCode: ManagementFormErrorMessageNl,
+		TooFewFormsErrorCode:    TooFewFormsErrorMessageNl,
+		TooManyFormsErrorCode:   TooManyFormsErrorMessageNl,
+		OrderErrorCode:          OrderErrorMessageNl,
+	}
 )
 
+// bundledMessages returns a copy of the messages of the bundled languages
+// other than English and French, and of the English and French summary
+// messages. They are looked up by errors not produced by go-playground
+// validator, e.g. the required error of a BooleanField.
+func bundledMessages() map[language.Tag]Messages {
+	bundled := map[language.Tag][]Messages{
+		language.English:    {errorSummariesEn},
+		language.French:     {errorSummariesFr},
+		language.German:     {validatorErrorMessagesDe, formSetErrorMessagesDe},
+		language.Spanish:    {validatorErrorMessagesEs, formSetErrorMessagesEs},
+		language.Italian:    {validatorErrorMessagesIt, formSetErrorMessagesIt},
+		language.Portuguese: {validatorErrorMessagesPt, formSetErrorMessagesPt},
+		language.Dutch:      {validatorErrorMessagesNl, formSetErrorMessagesNl},
+	}
+	output := map[language.Tag]Messages{}
+	for tag, list := range bundled {
+		output[tag] = Messages{}
+		for _, messages := range list {
+			for code, message := range messages {
+				output[tag][code] = message
+			}
+		}
+	}
+	return output
+}
+
 var defaultLanguage = language.English
 
 var universalTranslator *ut.UniversalTranslator
@@ -45,129 +261,88 @@ var universalTranslator *ut.UniversalTranslator
 func setValidationTranslations(validate *validator.Validate) {
 	en := english.New()
 	fr := french.New()
-	universalTranslator = ut.New(en, en, fr)
+	universalTranslator = ut.New(en, en, fr, german.New(), spanish.New(), italian.New(), portuguese.New(), dutch.New())
 	enTrans, _ := universalTranslator.GetTranslator(language.English.String())
 	setEnValidationTranslations(validate, enTrans)
 	frTrans, _ := universalTranslator.GetTranslator(language.French.String())
 	setFrValidationTranslations(validate, frTrans)
+	deTrans, _ := universalTranslator.GetTranslator(language.German.String())
+	setDeValidationTranslations(validate, deTrans)
+	esTrans, _ := universalTranslator.GetTranslator(language.Spanish.String())
+	setEsValidationTranslations(validate, esTrans)
+	itTrans, _ := universalTranslator.GetTranslator(language.Italian.String())
+	setItValidationTranslations(validate, itTrans)
+	ptTrans, _ := universalTranslator.GetTranslator(language.Portuguese.String())
+	setPtValidationTranslations(validate, ptTrans)
+	nlTrans, _ := universalTranslator.GetTranslator(language.Dutch.String())
+	setNlValidationTranslations(validate, nlTrans)
 }
 
 func setEnValidationTranslations(validate *validator.Validate, trans ut.Translator) {
 	if err := en_translations.RegisterDefaultTranslations(validate, trans); err != nil {
 		panic(fmt.Sprintf("fail to load en form error trans %s", err.Error()))
 	}
-	_ = validate.RegisterTranslation(BooleanErrorCode, trans, func(ut ut.Translator) error {
-		return ut.Add(BooleanErrorCode, BooleanErrorMessageEn, true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-		t, _ := ut.T(BooleanErrorCode)
-		return t
-	})
-	_ = validate.RegisterTranslation(EmailErrorCode, trans, func(ut ut.Translator) error {
-		return ut.Add(EmailErrorCode, EmailErrorMessageEn, true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-		t, _ := ut.T(EmailErrorCode)
-		return t
-	})
-	_ = validate.RegisterTranslation(ChoiceErrorCode, trans, func(ut ut.Translator) error {
-		return ut.Add(ChoiceErrorCode, ChoiceErrorMessageEn, true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-		t, _ := ut.T(ChoiceErrorCode)
-		return t
-	})
-	_ = validate.RegisterTranslation(MinLengthErrorCode, trans, func(ut ut.Translator) error {
-		return ut.Add(MinLengthErrorCode, MinLengthErrorMessageEn, true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-
-		t, _ := ut.T(MinLengthErrorCode, fe.Param())
-		return t
-	})
-	_ = validate.RegisterTranslation(MaxLengthErrorCode, trans, func(ut ut.Translator) error {
-		return ut.Add(MaxLengthErrorCode, MaxLengthErrorMessageEn, true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-
-		t, _ := ut.T(MaxLengthErrorCode, fe.Param())
-		return t
-	})
-	_ = validate.RegisterTranslation(RequiredErrorCode, trans, func(ut ut.Translator) error {
-		return ut.Add(RequiredErrorCode, RequiredErrorMessageEn, true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-		t, _ := ut.T(RequiredErrorCode)
-		return t
-	})
-	_ = validate.RegisterTranslation(URLErrorCode, trans, func(ut ut.Translator) error {
-		return ut.Add(URLErrorCode, URLErrorMessageEn, true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-		t, _ := ut.T(URLErrorCode)
-		return t
-	})
+	setValidationMessages(validate, trans, validatorErrorMessagesEn)
 }
 
 func setFrValidationTranslations(validate *validator.Validate, trans ut.Translator) {
 	if err := fr_translations.RegisterDefaultTranslations(validate, trans); err != nil {
 		panic(fmt.Sprintf("fail to load en form error trans %s", err.Error()))
 	}
-	_ = validate.RegisterTranslation(BooleanErrorCode, trans, func(ut ut.Translator) error {
-		return ut.Add(BooleanErrorCode, BooleanErrorMessageFr, true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-		t, _ := ut.T(BooleanErrorCode)
-		return t
-	})
-	_ = validate.RegisterTranslation(EmailErrorCode, trans, func(ut ut.Translator) error {
-		return ut.Add(EmailErrorCode, EmailErrorMessageFr, true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-		t, _ := ut.T(EmailErrorCode)
-		return t
-	})
-	_ = validate.RegisterTranslation(ChoiceErrorCode, trans, func(ut ut.Translator) error {
-		return ut.Add(ChoiceErrorCode, ChoiceErrorMessageFr, true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-		t, _ := ut.T(ChoiceErrorCode)
-		return t
-	})
-	_ = validate.RegisterTranslation(MinLengthErrorCode, trans, func(ut ut.Translator) error {
-		return ut.Add(MinLengthErrorCode, MinLengthErrorMessageFr, true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-
-		t, _ := ut.T(MinLengthErrorCode, fe.Param())
-		return t
-	})
-	_ = validate.RegisterTranslation(MaxLengthErrorCode, trans, func(ut ut.Translator) error {
-		return ut.Add(MaxLengthErrorCode, MaxLengthErrorMessageFr, true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-
-		t, _ := ut.T(MaxLengthErrorCode, fe.Param())
-		return t
-	})
-	_ = validate.RegisterTranslation(RequiredErrorCode, trans, func(ut ut.Translator) error {
-		return ut.Add(RequiredErrorCode, RequiredErrorMessageFr, true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-		t, _ := ut.T(RequiredErrorCode)
-		return t
-	})
-	_ = validate.RegisterTranslation(URLErrorCode, trans, func(ut ut.Translator) error {
-		return ut.Add(URLErrorCode, URLErrorMessageFr, true)
-	}, func(ut ut.Translator, fe validator.FieldError) string {
-		t, _ := ut.T(URLErrorCode)
-		return t
-	})
+	setValidationMessages(validate, trans, validatorErrorMessagesFr)
+}
+
+// setDeValidationTranslations registers the German messages. go-playground
+// validator has no German default translations.
+func setDeValidationTranslations(validate *validator.Validate, trans ut.Translator) {
+	setValidationMessages(validate, trans, validatorErrorMessagesDe)
+}
+
+func setEsValidationTranslations(validate *validator.Validate, trans ut.Translator) {
+	if err := es_translations.RegisterDefaultTranslations(validate, trans); err != nil {
+		panic(fmt.Sprintf("fail to load es form error trans %s", err.Error()))
+	}
+	setValidationMessages(validate, trans, validatorErrorMessagesEs)
+}
+
+func setItValidationTranslations(validate *validator.Validate, trans ut.Translator) {
+	if err := it_translations.RegisterDefaultTranslations(validate, trans); err != nil {
+		panic(fmt.Sprintf("fail to load it form error trans %s", err.Error()))
+	}
+	setValidationMessages(validate, trans, validatorErrorMessagesIt)
+}
+
+func setPtValidationTranslations(validate *validator.Validate, trans ut.Translator) {
+	if err := pt_translations.RegisterDefaultTranslations(validate, trans); err != nil {
+		panic(fmt.Sprintf("fail to load pt form error trans %s", err.Error()))
+	}
+	setValidationMessages(validate, trans, validatorErrorMessagesPt)
+}
+
+func setNlValidationTranslations(validate *validator.Validate, trans ut.Translator) {
+	if err := nl_translations.RegisterDefaultTranslations(validate, trans); err != nil {
+		panic(fmt.Sprintf("fail to load nl form error trans %s", err.Error()))
+	}
+	setValidationMessages(validate, trans, validatorErrorMessagesNl)
+}
+
+func setValidationMessages(validate *validator.Validate, trans ut.Translator, messages Messages) {
+	for _, code := range validatorErrorCodes {
+		_ = registerValidatorTranslation(validate, trans, code, messages[code])
+	}
 }
 
 func selectLanguage(availableLanguages []language.Tag, matchingLangStrings ...string) language.Tag {
 	matcher := language.NewMatcher(availableLanguages)
-	l, _ := language.MatchStrings(matcher, matchingLangStrings...)
-	if l == language.Und {
+	l, index := language.MatchStrings(matcher, matchingLangStrings...)
+	if l == language.Und || index >= len(availableLanguages) {
 		return defaultLanguage
 	}
-	if !slices.Contains(languageStrings(languages), l.String()) {
+	// The matched tag can have a region extension, e.g. "de-u-rg-chzzzz" for
+	// "de-CH". The supported tag is returned instead.
+	l = availableLanguages[index]
+	if !isSupportedLocale(l) {
 		return defaultLanguage
 	}
 	return l
 }
-
-func languageStrings(langs []language.Tag) []string {
-	s := make([]string, len(langs))
-	for i, l := range langs {
-		s[i] = l.String()
-	}
-	return s
-}
@@ -95,7 +95,7 @@ func TestExportSelectLanguage(t *testing.T) {
 				availableLanguages:  aform.ExportLanguages,
 				matchingLangStrings: []string{"de-CH"},
 			},
-			want: language.English,
+			want: language.German,
 		},
 		{
 			name: "Accept-Language: de-CH than fr",
@@ -103,8 +103,56 @@ func TestExportSelectLanguage(t *testing.T) {
 				availableLanguages:  aform.ExportLanguages,
 				matchingLangStrings: []string{"de-CH", "fr"},
 			},
+			want: language.German,
+		},
+		{
+			name: "Accept-Language: da-DK than fr",
+			args: args{
+				availableLanguages:  aform.ExportLanguages,
+				matchingLangStrings: []string{"da-DK", "fr"},
+			},
 			want: language.French,
 		},
+		{
+			name: "Test de",
+			args: args{
+				availableLanguages:  aform.ExportLanguages,
+				matchingLangStrings: []string{"de"},
+			},
+			want: language.German,
+		},
+		{
+			name: "Test es",
+			args: args{
+				availableLanguages:  aform.ExportLanguages,
+				matchingLangStrings: []string{"es"},
+			},
+			want: language.Spanish,
+		},
+		{
+			name: "Test it",
+			args: args{
+				availableLanguages:  aform.ExportLanguages,
+				matchingLangStrings: []string{"it"},
+			},
+			want: language.Italian,
+		},
+		{
+			name: "Test pt",
+			args: args{
+				availableLanguages:  aform.ExportLanguages,
+				matchingLangStrings: []string{"pt"},
+			},
+			want: language.Portuguese,
+		},
+		{
+			name: "Test nl",
+			args: args{
+				availableLanguages:  aform.ExportLanguages,
+				matchingLangStrings: []string{"nl"},
+			},
+			want: language.Dutch,
+		},
 		{
 			name: "No languages and no string",
 			args: args{
@@ -144,3 +192,81 @@ func TestExportSelectLanguage(t *testing.T) {
 		})
 	}
 }
+
+func TestBundledTranslations(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale language.Tag
+		want   map[string]string
+	}{
+		{
+			name:   "German",
+			locale: language.German,
+			want: map[string]string{
+				"email":    aform.EmailErrorMessageDe,
+				"password": "Stellen Sie sicher, dass dieser Wert mindestens 8 Zeichen hat",
+				"terms":    aform.RequiredErrorMessageDe,
+				"country":  aform.ChoiceErrorMessageDe,
+			},
+		},
+		{
+			name:   "Spanish",
+			locale: language.Spanish,
+			want: map[string]string{
+				"email":    aform.EmailErrorMessageEs,
+				"password": "Asegúrese de que este valor tenga al menos 8 caracteres",
+				"terms":    aform.RequiredErrorMessageEs,
+				"country":  aform.ChoiceErrorMessageEs,
+			},
+		},
+		{
+			name:   "Italian",
+			locale: language.Italian,
+			want: map[string]string{
+				"email":    aform.EmailErrorMessageIt,
+				"password": "Assicurati che questo valore contenga almeno 8 caratteri",
+				"terms":    aform.RequiredErrorMessageIt,
+				"country":  aform.ChoiceErrorMessageIt,
+			},
+		},
+		{
+			name:   "Portuguese",
+			locale: language.Portuguese,
+			want: map[string]string{
+				"email":    aform.EmailErrorMessagePt,
+				"password": "Certifique-se de que este valor tem pelo menos 8 caracteres",
+				"terms":    aform.RequiredErrorMessagePt,
+				"country":  aform.ChoiceErrorMessagePt,
+			},
+		},
+		{
+			name:   "Dutch",
+			locale: language.Dutch,
+			want: map[string]string{
+				"email":    aform.EmailErrorMessageNl,
+				"password": "Zorg ervoor dat deze waarde minstens 8 tekens bevat",
+				"terms":    aform.RequiredErrorMessageNl,
+				"country":  aform.ChoiceErrorMessageNl,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			f := aform.Must(aform.New(
+				aform.WithLocales([]language.Tag{language.English, tt.locale}),
+				aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))),
+				aform.WithCharField(aform.Must(aform.NewCharField("Password", "", "", 8, 0))),
+				aform.WithBooleanField(aform.Must(aform.DefaultBooleanField("Terms"))),
+				aform.WithChoiceField(aform.Must(aform.DefaultChoiceField("Country", aform.WithChoiceOptions([]aform.ChoiceFieldOption{{Value: "fr", Label: "France"}})))),
+			))
+			f.BindData(map[string][]string{"email": {"jane"}, "password": {"secret"}, "country": {"de"}}, tt.locale.String())
+			a.False(f.IsValid())
+			got := map[string]string{}
+			for name, errs := range f.Errors() {
+				got[name] = errs[0].Translate(tt.locale.String())
+			}
+			a.Equal(tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package aform
+
+import (
+	"context"
+)
+
+// CanceledErrorCode is the error code of the error added when the context of
+// the form is canceled or its deadline is exceeded during validation.
+const CanceledErrorCode = "canceled"
+
+// English and French error messages of an interrupted validation.
+const (
+	CanceledErrorMessageEn = "Validation has been interrupted. Submit the form again"
+	CanceledErrorMessageFr = "La validation a été interrompue. Soumettez à nouveau le formulaire"
+)
+
+// ValidationContextFunc defines a function to validate a Field with the
+// context of the form. See SetValidateContextFunc.
+type ValidationContextFunc func(context.Context, string, bool) []Error
+
+// SetValidateContextFunc is like SetValidateFunc for validation functions
+// needing a context, e.g. to query a database. The context is the one of the
+// request given to Form.BindRequest, or context.Background if the form is
+// bound with BindData or if the field is cleaned outside a form. Parameter
+// update gets the current validation function, built from the function set
+// with SetValidateFunc if any.
+func (fld *Field) SetValidateContextFunc(update func(current ValidationContextFunc) (new ValidationContextFunc)) {
+	fld.validateCtxFunc = update(fld.currentValidateContextFunc())
+}
+
+func (fld *Field) currentValidateContextFunc() ValidationContextFunc {
+	if fld.validateCtxFunc != nil {
+		return fld.validateCtxFunc
+	}
+	validateFunc := fld.validateFunc
+	return func(_ context.Context, value string, required bool) []Error {
+		return validateFunc(value, required)
+	}
+}
+
+// validate validates value with the function set with SetValidateContextFunc
+// if any, with the function set with SetValidateFunc otherwise.
+// Validators added with AddValidators are run after.
+func (fld *Field) validate(value string, required bool) []Error {
+	var errs []Error
+	if fld.validateCtxFunc != nil {
+		errs = fld.validateCtxFunc(fld.context(), value, required)
+	} else {
+		errs = fld.validateFunc(value, required)
+	}
+	return fld.runValidators(value, errs)
+}
+
+func (fld *Field) context() context.Context {
+	if fld.ctx == nil {
+		return context.Background()
+	}
+	return fld.ctx
+}
+
+// SetCleanContextFunc is like SetCleanFunc for clean functions needing a
+// context. The context is the one of the request given to BindRequest, or
+// context.Background if the form is bound with BindData.
+func (f *Form) SetCleanContextFunc(clean func(context.Context, *Form)) {
+	f.cleanFunc = func(f *Form) {
+		clean(f.context(), f)
+	}
+}
+
+func (f *Form) context() context.Context {
+	if f.ctx == nil {
+		return context.Background()
+	}
+	return f.ctx
+}
+
+// canceledError is the error added when the validation is interrupted. It
+// wraps the context error, so errors.Is(err, context.Canceled) works.
+type canceledError struct {
+	simpleError
+	err error
+}
+
+func (e canceledError) Unwrap() error {
+	return e.err
+}
+
+func newCanceledError(err error) Error {
+	return ErrorWrap(canceledError{
+		simpleError: simpleError{code: CanceledErrorCode, fr: CanceledErrorMessageFr, en: CanceledErrorMessageEn},
+		err:         err,
+	})
+}
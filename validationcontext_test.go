@@ -0,0 +1,143 @@
+package aform_test
+
+import (
+	"context"
+	"errors"
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type ctxKey struct{}
+
+var errEmailTaken = errors.New("Email already registered")
+
+func TestField_SetValidateContextFunc(t *testing.T) {
+	tests := []struct {
+		name       string
+		registered string
+		wantValid  bool
+	}{
+		{name: "email available", registered: "john@example.com", wantValid: true},
+		{name: "email registered", registered: "jane@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			fld := aform.Must(aform.DefaultEmailField("Email"))
+			fld.SetValidateContextFunc(func(current aform.ValidationContextFunc) aform.ValidationContextFunc {
+				return func(ctx context.Context, value string, required bool) []aform.Error {
+					if errs := current(ctx, value, required); len(errs) > 0 {
+						return errs
+					}
+					a.Equal("request", ctx.Value(ctxKey{}))
+					if value == tt.registered {
+						return []aform.Error{aform.ErrorWrap(errEmailTaken)}
+					}
+					return nil
+				}
+			})
+			f := aform.Must(aform.New(aform.WithEmailField(fld)))
+			req := httptest.NewRequest(http.MethodGet, "/?email=jane@example.com", nil)
+			a.NoError(f.BindRequest(req.WithContext(context.WithValue(req.Context(), ctxKey{}, "request"))))
+			a.Equal(tt.wantValid, f.IsValid())
+			if !tt.wantValid {
+				a.Equal(errEmailTaken.Error(), f.Errors().Get("email").Error())
+			}
+		})
+	}
+}
+
+func TestField_SetValidateFunc_afterContextFunc(t *testing.T) {
+	a := assert.New(t)
+	fld := aform.Must(aform.DefaultCharField("Name"))
+	fld.SetValidateContextFunc(func(current aform.ValidationContextFunc) aform.ValidationContextFunc {
+		return func(ctx context.Context, value string, required bool) []aform.Error {
+			if value == "admin" {
+				return []aform.Error{aform.ErrorWrap(errors.New("reserved"))}
+			}
+			return current(ctx, value, required)
+		}
+	})
+	fld.SetValidateFunc(func(current aform.ValidationFunc) aform.ValidationFunc {
+		return func(value string, required bool) []aform.Error {
+			if value == "root" {
+				return []aform.Error{aform.ErrorWrap(errors.New("reserved"))}
+			}
+			return current(value, required)
+		}
+	})
+	for _, value := range []string{"admin", "root"} {
+		_, errs := fld.Clean(value)
+		a.Len(errs, 1)
+	}
+	_, errs := fld.Clean("jane")
+	a.Empty(errs)
+}
+
+func TestForm_SetCleanContextFunc(t *testing.T) {
+	a := assert.New(t)
+	f := aform.Must(aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+	var got interface{}
+	f.SetCleanContextFunc(func(ctx context.Context, f *aform.Form) {
+		got = ctx.Value(ctxKey{})
+	})
+	req := httptest.NewRequest(http.MethodGet, "/?name=Jane", nil)
+	a.NoError(f.BindRequest(req.WithContext(context.WithValue(req.Context(), ctxKey{}, "request"))))
+	a.True(f.IsValid())
+	a.Equal("request", got)
+}
+
+func TestForm_IsValid_canceledContext(t *testing.T) {
+	a := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cleaned := false
+	validated := false
+	fld := aform.Must(aform.DefaultCharField("Name"))
+	fld.SetValidateContextFunc(func(current aform.ValidationContextFunc) aform.ValidationContextFunc {
+		return func(ctx context.Context, value string, required bool) []aform.Error {
+			validated = true
+			cancel()
+			return current(ctx, value, required)
+		}
+	})
+	f := aform.Must(aform.New(aform.WithCharField(fld), aform.WithCharField(aform.Must(aform.DefaultCharField("City")))))
+	f.SetCleanFunc(func(f *aform.Form) {
+		cleaned = true
+	})
+	req := httptest.NewRequest(http.MethodGet, "/?name=Jane&city=Paris", nil)
+	a.NoError(f.BindRequest(req.WithContext(ctx)))
+	a.False(f.IsValid())
+	a.True(validated)
+	a.False(cleaned)
+	a.False(f.CleanedData().Has("city"))
+	err := f.Errors().Get(aform.NonFieldErrorsKey)
+	a.Equal(aform.CanceledErrorCode, err.Code())
+	a.True(errors.Is(err, context.Canceled))
+}
+
+func TestFormSet_BindRequest_canceledContext(t *testing.T) {
+	a := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	fs := aform.Must(aform.NewFormSet(func(index int) (*aform.Form, error) {
+		fld := aform.Must(aform.DefaultCharField("Name"))
+		fld.SetValidateContextFunc(func(current aform.ValidationContextFunc) aform.ValidationContextFunc {
+			return func(ctx context.Context, value string, required bool) []aform.Error {
+				a.Equal("request", ctx.Value(ctxKey{}))
+				cancel()
+				return current(ctx, value, required)
+			}
+		})
+		return aform.New(aform.WithCharField(fld), aform.WithCharField(aform.Must(aform.DefaultCharField("City"))))
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/?form-total_forms=1&form-initial_forms=0&form-0-name=Jane&form-0-city=Paris", nil)
+	a.NoError(fs.BindRequest(req.WithContext(context.WithValue(ctx, ctxKey{}, "request"))))
+	a.False(fs.IsValid())
+	f := fs.Forms()[0]
+	a.False(f.CleanedData().Has("city"))
+	err := f.Errors().Get(aform.NonFieldErrorsKey)
+	a.Equal(aform.CanceledErrorCode, err.Code())
+	a.True(errors.Is(err, context.Canceled))
+}
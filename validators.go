@@ -0,0 +1,135 @@
+package aform
+
+import (
+	"golang.org/x/exp/slices"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator validates a sanitized value of a Field. It returns nil if value is
+// valid. The error returned can be an Error, e.g. to set its code with
+// ErrorWrapWithCode, or any error. Validators are added to a field with
+// WithValidators or Field.AddValidators.
+type Validator func(value string) error
+
+// WithValidators returns a FieldOption that adds validators to the Field. See
+// Field.AddValidators.
+func WithValidators(validators ...Validator) FieldOption {
+	return func(fld *Field) error {
+		fld.AddValidators(validators...)
+		return nil
+	}
+}
+
+// StopOnFirstError returns a FieldOption that stops the validation of the
+// Field at the first error. See Field.SetStopOnFirstError.
+func StopOnFirstError() FieldOption {
+	return func(fld *Field) error {
+		fld.SetStopOnFirstError()
+		return nil
+	}
+}
+
+// AddValidators adds validators run after the default validation of the
+// field type, in the order they are added. They are not run on empty values:
+// required values are checked by the default validation. All the errors are
+// returned unless SetStopOnFirstError is used. Errors can be customized with
+// CustomizeError like built-in errors.
+func (fld *Field) AddValidators(validators ...Validator) {
+	fld.validators = append(fld.validators, validators...)
+}
+
+// SetStopOnFirstError stops the validation of the Field at the first error.
+// Validators added with AddValidators are not run if the default validation
+// fails, and the following validators are not run once one of them fails.
+func (fld *Field) SetStopOnFirstError() {
+	fld.stopOnFirstError = true
+}
+
+// runValidators returns errs, the errors of the default validation, followed
+// by the errors of the validators.
+func (fld *Field) runValidators(value string, errs []Error) []Error {
+	if fld.stopOnFirstError && len(errs) > 0 {
+		return errs[:1]
+	}
+	if len(value) == 0 {
+		return errs
+	}
+	for _, v := range fld.validators {
+		err := v(value)
+		if err == nil {
+			continue
+		}
+		errs = append(errs, errorWrapIfNotAsError(err))
+		if fld.stopOnFirstError {
+			return errs
+		}
+	}
+	return errs
+}
+
+// MinLength returns a Validator that validates the value has at least min
+// characters. Its error code is MinLengthErrorCode.
+func MinLength(min uint) Validator {
+	return func(value string) error {
+		if uint(utf8.RuneCountInString(value)) >= min {
+			return nil
+		}
+		return lengthError(MinLengthErrorCode, MinLengthErrorMessageEn, MinLengthErrorMessageFr, min)
+	}
+}
+
+// MaxLength returns a Validator that validates the value has at most max
+// characters. Its error code is MaxLengthErrorCode.
+func MaxLength(max uint) Validator {
+	return func(value string) error {
+		if uint(utf8.RuneCountInString(value)) <= max {
+			return nil
+		}
+		return lengthError(MaxLengthErrorCode, MaxLengthErrorMessageEn, MaxLengthErrorMessageFr, max)
+	}
+}
+
+// Regex returns a Validator that validates the value matches re. Its error
+// code is RegexErrorCode.
+func Regex(re *regexp.Regexp) Validator {
+	return func(value string) error {
+		if re.MatchString(value) {
+			return nil
+		}
+		return ErrorWrap(simpleError{code: RegexErrorCode, fr: RegexErrorMessageFr, en: RegexErrorMessageEn})
+	}
+}
+
+// NotIn returns a Validator that validates the value is not one of values,
+// e.g. reserved user names. Comparison is case-insensitive. Its error code is
+// NotInErrorCode.
+func NotIn(values ...string) Validator {
+	lowerValues := make([]string, len(values))
+	for i, v := range values {
+		lowerValues[i] = strings.ToLower(v)
+	}
+	return func(value string) error {
+		if !slices.Contains(lowerValues, strings.ToLower(value)) {
+			return nil
+		}
+		return ErrorWrap(simpleError{code: NotInErrorCode, fr: NotInErrorMessageFr, en: NotInErrorMessageEn})
+	}
+}
+
+// Integer returns a Validator that validates the value is a whole number.
+// Its error code is IntegerErrorCode.
+func Integer() Validator {
+	return func(value string) error {
+		if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return nil
+		}
+		return ErrorWrap(simpleError{code: IntegerErrorCode, fr: IntegerErrorMessageFr, en: IntegerErrorMessageEn})
+	}
+}
+
+func lengthError(code, en, fr string, length uint) Error {
+	return ErrorWrap(simpleError{code: code, fr: fr, en: en, params: []string{strconv.FormatUint(uint64(length), 10)}})
+}
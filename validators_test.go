@@ -0,0 +1,125 @@
+package aform_test
+
+import (
+	"errors"
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestValidators(t *testing.T) {
+	slug := regexp.MustCompile(`^[a-z0-9-]+$`)
+	tests := []struct {
+		name      string
+		validator aform.Validator
+		value     string
+		wantCode  string
+		wantEn    string
+		wantFr    string
+	}{
+		{name: "min length valid", validator: aform.MinLength(3), value: "été"},
+		{name: "min length invalid", validator: aform.MinLength(3), value: "ab", wantCode: aform.MinLengthErrorCode, wantEn: "Ensure this value has at least 3 characters", wantFr: "Assurez-vous que cette valeur fait au minimum 3 caractères"},
+		{name: "max length valid", validator: aform.MaxLength(3), value: "abc"},
+		{name: "max length invalid", validator: aform.MaxLength(3), value: "abcd", wantCode: aform.MaxLengthErrorCode, wantEn: "Ensure this value has at most 3 characters", wantFr: "Assurez-vous que cette valeur fait au maximum 3 caractères"},
+		{name: "regex valid", validator: aform.Regex(slug), value: "senior-go-developer"},
+		{name: "regex invalid", validator: aform.Regex(slug), value: "Senior Go", wantCode: aform.RegexErrorCode, wantEn: aform.RegexErrorMessageEn, wantFr: aform.RegexErrorMessageFr},
+		{name: "not in valid", validator: aform.NotIn("admin", "root"), value: "jane"},
+		{name: "not in invalid", validator: aform.NotIn("admin", "root"), value: "Admin", wantCode: aform.NotInErrorCode, wantEn: aform.NotInErrorMessageEn, wantFr: aform.NotInErrorMessageFr},
+		{name: "integer valid", validator: aform.Integer(), value: "-42"},
+		{name: "integer invalid", validator: aform.Integer(), value: "4.2", wantCode: aform.IntegerErrorCode, wantEn: aform.IntegerErrorMessageEn, wantFr: aform.IntegerErrorMessageFr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			err := tt.validator(tt.value)
+			if tt.wantCode == "" {
+				a.NoError(err)
+				return
+			}
+			var e aform.Error
+			if a.True(errors.As(err, &e)) {
+				a.Equal(tt.wantCode, e.Code())
+				a.Equal(tt.wantEn, e.Translate("en"))
+				a.Equal(tt.wantFr, e.Translate("fr"))
+			}
+		})
+	}
+}
+
+func TestField_AddValidators(t *testing.T) {
+	errCustom := errors.New("must not start with a dash")
+	noLeadingDash := func(value string) error {
+		if value[0] == '-' {
+			return errCustom
+		}
+		return nil
+	}
+	tests := []struct {
+		name      string
+		opts      []aform.FieldOption
+		value     string
+		wantCodes []string
+	}{
+		{
+			name:  "valid",
+			opts:  []aform.FieldOption{aform.WithValidators(aform.Regex(regexp.MustCompile(`^[a-z-]+$`)), aform.NotIn("admin"))},
+			value: "jane",
+		},
+		{
+			name:      "all the errors",
+			opts:      []aform.FieldOption{aform.WithValidators(aform.Regex(regexp.MustCompile(`^[a-z]+$`)), noLeadingDash)},
+			value:     "-jane",
+			wantCodes: []string{aform.RegexErrorCode, ""},
+		},
+		{
+			name:      "stop on first validator error",
+			opts:      []aform.FieldOption{aform.StopOnFirstError(), aform.WithValidators(aform.Regex(regexp.MustCompile(`^[a-z]+$`)), noLeadingDash)},
+			value:     "-jane",
+			wantCodes: []string{aform.RegexErrorCode},
+		},
+		{
+			name:      "default validation runs first",
+			opts:      []aform.FieldOption{aform.WithValidators(aform.NotIn("administrator"))},
+			value:     "administrator",
+			wantCodes: []string{aform.MaxLengthErrorCode, aform.NotInErrorCode},
+		},
+		{
+			name:      "stop on default validation error",
+			opts:      []aform.FieldOption{aform.StopOnFirstError(), aform.WithValidators(aform.NotIn("administrator"))},
+			value:     "administrator",
+			wantCodes: []string{aform.MaxLengthErrorCode},
+		},
+		{
+			name:      "validators don't run on empty values",
+			opts:      []aform.FieldOption{aform.WithValidators(aform.MinLength(3))},
+			value:     "",
+			wantCodes: []string{aform.RequiredErrorCode},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			fld := aform.Must(aform.NewCharField("Username", "", "", 0, 8, tt.opts...))
+			_, errs := fld.Clean(tt.value)
+			codes := make([]string, len(errs))
+			for i, err := range errs {
+				codes[i] = err.Code()
+			}
+			a.Equal(len(tt.wantCodes), len(codes))
+			if len(tt.wantCodes) > 0 {
+				a.Equal(tt.wantCodes, codes)
+			}
+		})
+	}
+}
+
+func TestField_AddValidators_CustomizeError(t *testing.T) {
+	a := assert.New(t)
+	fld := aform.Must(aform.DefaultCharField("Username", aform.WithValidators(aform.NotIn("admin"))))
+	fld.CustomizeError(aform.ErrorWrapWithCode(errors.New("This username is reserved"), aform.NotInErrorCode))
+	f := aform.Must(aform.New(aform.WithCharField(fld)))
+	f.BindData(map[string][]string{"username": {"admin"}})
+	a.False(f.IsValid())
+	a.Equal("This username is reserved", f.Errors().Get("username").Error())
+}
@@ -0,0 +1,59 @@
+package aform
+
+import "html/template"
+
+// AddWarning adds a warning to the field. A warning informs the user without
+// blocking the submission: it is rendered like an error but it doesn't make
+// the form invalid. It can be added by a validation function or by the form
+// clean function. Warnings are cleared when the form is validated. Warnings
+// are translated like errors with TranslateError.
+func (fld *Field) AddWarning(warning error) {
+	fld.warnings = append(fld.warnings, errorWrapIfNotAsError(warning))
+}
+
+// HasWarnings returns true if there is at least one warning.
+func (fld *Field) HasWarnings() bool {
+	return len(fld.warnings) > 0
+}
+
+// Warnings renders field warnings in a <ul> tag with each <li> children tag
+// containing one warning. CSS class warninglist is set on the <ul> tag. Each
+// <li> tag as a generated unique ID based on the warning index and the field
+// ID. The IDs are added to the widget aria-describedby attribute.
+func (fld *Field) Warnings() template.HTML {
+	if !fld.HasWarnings() {
+		return ""
+	}
+	list := make([]tmplError, len(fld.warnings))
+	for i, warning := range fld.warnings {
+		attrs := tmplAttrs{}
+		if hasID(fld) {
+			attrs["id"] = normalizedDescribedByIDForWarning(fld, i)
+		}
+		list[i] = tmplError{
+			Text:  fld.TranslateError(warning),
+			Attrs: attrs,
+		}
+	}
+	return mustErrorsTemplate(&tmplErrors{
+		List:  list,
+		Attrs: map[string]string{"class": "warninglist"},
+	})
+}
+
+// Warnings returns the warnings added to the fields during validation. It
+// does Form validation if it is not already done. Warnings don't make the
+// form invalid. A field without warning doesn't appear in the map.
+func (f *Form) Warnings() FormErrors {
+	output := FormErrors{}
+	if !f.IsBound() {
+		return output
+	}
+	f.doValidationIfNeeded()
+	for _, fld := range f.fields {
+		if warnings := fld.field().warnings; len(warnings) > 0 {
+			output[normalizedNameForField(fld)] = append([]Error{}, warnings...)
+		}
+	}
+	return output
+}
@@ -0,0 +1,81 @@
+package aform_test
+
+import (
+	"errors"
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+var errMistypedDomain = errors.New("This email domain looks mistyped")
+
+func TestForm_Warnings(t *testing.T) {
+	a := assert.New(t)
+	email := aform.Must(aform.DefaultEmailField("Email"))
+	email.SetValidateFunc(func(current aform.ValidationFunc) aform.ValidationFunc {
+		return func(value string, required bool) []aform.Error {
+			if strings.HasSuffix(value, "@gmial.com") {
+				email.AddWarning(errMistypedDomain)
+			}
+			return current(value, required)
+		}
+	})
+	f := aform.Must(aform.New(aform.WithEmailField(email), aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+	a.Empty(f.Warnings())
+	f.BindData(map[string][]string{"email": {"jane@gmial.com"}, "name": {"Jane"}})
+	a.True(f.IsValid())
+	a.Empty(f.Errors())
+	warnings := f.Warnings()
+	a.Len(warnings, 1)
+	a.Equal(errMistypedDomain.Error(), warnings.Get("email").Error())
+	a.False(warnings.Has("name"))
+	expected := `
+<div><label for="id_email">Email</label>
+<ul class="warninglist"><li id="warn_0_id_email">This email domain looks mistyped</li></ul>
+<input type="email" name="email" value="jane@gmial.com" id="id_email" maxlength="254" aria-describedby="warn_0_id_email" required></div>
+<div><label for="id_name">Name</label><input type="text" name="name" value="Jane" id="id_name" maxlength="256" required></div>`
+	a.Equal(expected, string(f.AsDiv()))
+}
+
+func TestForm_Warnings_withErrors(t *testing.T) {
+	a := assert.New(t)
+	email := aform.Must(aform.DefaultEmailField("Email"))
+	email.SetValidateFunc(func(current aform.ValidationFunc) aform.ValidationFunc {
+		return func(value string, required bool) []aform.Error {
+			if strings.HasSuffix(value, "@gmial.com") {
+				email.AddWarning(errMistypedDomain)
+			}
+			return current(value, required)
+		}
+	})
+	f := aform.Must(aform.New(aform.WithEmailField(email), aform.WithCharField(aform.Must(aform.DefaultCharField("Name")))))
+	f.SetCleanFunc(func(f *aform.Form) {
+		_ = f.AddError("email", errors.New("Email already registered"))
+	})
+	f.BindData(map[string][]string{"email": {"jane@gmial.com"}, "name": {"Jane"}})
+	a.False(f.IsValid())
+	a.True(f.Warnings().Has("email"))
+	expected := `<div><label for="id_email">Email</label>
+<ul class="errorlist"><li id="err_0_id_email">Email already registered</li></ul>
+<ul class="warninglist"><li id="warn_0_id_email">This email domain looks mistyped</li></ul>
+<input type="email" name="email" value="jane@gmial.com" id="id_email" maxlength="254" aria-describedby="err_0_id_email warn_0_id_email" aria-invalid="true" required></div>`
+	fld, err := f.FieldByName("Email")
+	a.NoError(err)
+	a.Equal(expected, string(fld.AsDiv()))
+}
+
+func TestField_Warnings_namedPlaceholders(t *testing.T) {
+	a := assert.New(t)
+	fld := aform.Must(aform.DefaultCharField("Nickname"))
+	fld.SetValidateFunc(func(current aform.ValidationFunc) aform.ValidationFunc {
+		return func(value string, required bool) []aform.Error {
+			fld.AddWarning(errors.New("{label} {value} is already used"))
+			return current(value, required)
+		}
+	})
+	f := aform.Must(aform.New(aform.WithCharField(fld)))
+	f.BindData(map[string][]string{"nickname": {"jd"}})
+	a.True(f.IsValid())
+	a.Equal(`<ul class="warninglist"><li id="warn_0_id_nickname">Nickname jd is already used</li></ul>`, string(fld.Warnings()))
+}
@@ -92,6 +92,12 @@ func (t Widget) isChoice() bool {
 	return slices.Contains(list, t)
 }
 
+// isMultipleInput returns true if the widget is rendered as a list of inputs
+// in a <div> tag.
+func (t Widget) isMultipleInput() bool {
+	return t == RadioSelect || t == CheckboxSelectMultiple
+}
+
 func (t Widget) isMultiChoice() bool {
 	return t == SelectMultiple || t == CheckboxSelectMultiple
 }
@@ -0,0 +1,311 @@
+package aform
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultWizardPrefix = "wizard"
+)
+
+// Names of the fields used by a Wizard to manage steps. CurrentStepFieldName
+// is a hidden field rendered by Wizard.AsDiv. GotoStepFieldName is the name of
+// the submit button to go back to a previous step. See Wizard.GotoStepName.
+const (
+	CurrentStepFieldName = "current_step"
+	GotoStepFieldName    = "goto_step"
+)
+
+// Wizard splits one logical form across several steps. Each step is a Form
+// displayed on its own page. A step is validated when it's submitted and its
+// cleaned data is saved with a WizardStore. When the last step is valid,
+// cleaned data of all the steps is available with CleanedData.
+//
+// Like a Form, a Wizard must be created for each request.
+type Wizard struct {
+	forms        []*Form
+	store        WizardStore
+	prefix       string
+	current      int
+	data         WizardData
+	done         bool
+	management   *Form
+	maxBodyBytes int64
+	maxMemory    int64
+}
+
+// WizardOption describes a functional option for configuring a Wizard.
+type WizardOption func(*Wizard) error
+
+// NewWizard returns a Wizard with one step for each form in forms. Steps
+// order is forms order. Cleaned data of completed steps is saved with store.
+func NewWizard(forms []*Form, store WizardStore, opts ...WizardOption) (*Wizard, error) {
+	if len(forms) == 0 {
+		return nil, fmt.Errorf("a wizard must have at least one step")
+	}
+	if store == nil {
+		return nil, fmt.Errorf("a wizard must have a store")
+	}
+	wz := &Wizard{
+		forms:        forms,
+		store:        store,
+		prefix:       defaultWizardPrefix,
+		data:         WizardData{},
+		maxBodyBytes: defaultMaxBodyBytes,
+		maxMemory:    defaultMaxMemory,
+	}
+	for _, opt := range opts {
+		if err := opt(wz); err != nil {
+			return nil, err
+		}
+	}
+	fld, err := NewCharField(CurrentStepFieldName, "0", "", 0, 0, WithWidget(HiddenInput))
+	if err != nil {
+		return nil, err
+	}
+	management, err := New(WithPrefix(wz.prefix), WithCharField(fld))
+	if err != nil {
+		return nil, err
+	}
+	wz.management = management
+	for _, f := range forms {
+		f.managementKeys = append(f.managementKeys, prefixedName(wz.prefix, CurrentStepFieldName), wz.GotoStepName())
+	}
+	return wz, nil
+}
+
+// WithWizardPrefix returns a WizardOption that changes the prefix of the
+// fields used to manage steps. Default prefix is "wizard". It must be changed
+// if one of the forms has the same prefix.
+func WithWizardPrefix(prefix string) WizardOption {
+	return func(wz *Wizard) error {
+		if len(prefix) == 0 {
+			return fmt.Errorf("wizard prefix can't be empty")
+		}
+		wz.prefix = prefix
+		return nil
+	}
+}
+
+// WithWizardMaxBodyBytes returns a WizardOption that limits the size of the
+// request body read by Process. If the body is larger, Process returns an
+// error. n must be greater than 0. Default is 10MB.
+func WithWizardMaxBodyBytes(n int64) WizardOption {
+	return func(wz *Wizard) error {
+		if n <= 0 {
+			return fmt.Errorf("max body bytes must be greater than 0. Given: %d", n)
+		}
+		wz.maxBodyBytes = n
+		return nil
+	}
+}
+
+// WithWizardMaxMemory returns a WizardOption that sets the maximum size of a
+// multipart body stored in memory by Process. See WithMaxMemory.
+func WithWizardMaxMemory(n int64) WizardOption {
+	return func(wz *Wizard) error {
+		if n <= 0 {
+			return fmt.Errorf("max memory must be greater than 0. Given: %d", n)
+		}
+		wz.maxMemory = n
+		return nil
+	}
+}
+
+// Process handles a submitted step. The current step is read from the hidden
+// field rendered by AsDiv. If the request comes from the button named
+// GotoStepName, the wizard goes back to the requested step without
+// validation. Otherwise, the current step form is bound with BindRequest and
+// validated. If it is valid, its cleaned data is saved and the wizard moves
+// to the next step. Process returns true when the last step is valid. In that
+// case, data saved is cleared from the store and the cleaned data of all the
+// steps is available with CleanedData. Data loaded from the store for steps
+// the wizard doesn't have is ignored. Request is parsed with the limits set
+// with WithWizardMaxBodyBytes and WithWizardMaxMemory. An error is returned if
+// req body can't be parsed or if the store fails.
+func (wz *Wizard) Process(w http.ResponseWriter, req *http.Request) (bool, error) {
+	if err := parseRequest(req, wz.maxBodyBytes, wz.maxMemory); err != nil {
+		return false, err
+	}
+	data, err := wz.store.Load(req)
+	if err != nil {
+		return false, err
+	}
+	wz.data = WizardData{}
+	for step, cleanedData := range data {
+		if step >= 0 && step < len(wz.forms) {
+			wz.data[step] = cleanedData
+		}
+	}
+	for _, f := range wz.forms {
+		f.SetRequest(req)
+	}
+	step, ok := parseStep(req.Form.Get(prefixedName(wz.prefix, CurrentStepFieldName)), len(wz.forms))
+	if !ok || step > wz.firstIncompleteStep() {
+		wz.setCurrentStep(wz.resumeStep())
+		return false, nil
+	}
+	if gotoStep, ok := parseStep(req.Form.Get(wz.GotoStepName()), len(wz.forms)); ok && gotoStep < step {
+		wz.setCurrentStep(gotoStep)
+		return false, nil
+	}
+	wz.current = step
+	f := wz.forms[step]
+	if err := f.BindRequest(req); err != nil {
+		return false, err
+	}
+	if !f.IsValid() {
+		wz.setCurrentStep(step)
+		return false, nil
+	}
+	wz.data[step] = f.CleanedData()
+	next := step + 1
+	if next == len(wz.forms) {
+		next = wz.firstIncompleteStep()
+	}
+	if next < len(wz.forms) {
+		if err := wz.store.Save(w, req, wz.data); err != nil {
+			return false, err
+		}
+		wz.setCurrentStep(next)
+		return false, nil
+	}
+	if err := wz.store.Clear(w, req); err != nil {
+		return false, err
+	}
+	wz.done = true
+	return true, nil
+}
+
+// Reset clears data saved in the store and goes back to the first step.
+func (wz *Wizard) Reset(w http.ResponseWriter, req *http.Request) error {
+	wz.data = WizardData{}
+	wz.done = false
+	wz.setCurrentStep(0)
+	return wz.store.Clear(w, req)
+}
+
+// setCurrentStep changes the current step. If the form of the step is unbound
+// and its cleaned data has already been saved, the form is bound with it and
+// validated to render the saved values. CSRF, honeypot, timestamp and
+// one-time token protections are not checked: saved data has no token.
+func (wz *Wizard) setCurrentStep(step int) {
+	wz.current = step
+	wz.management.fields[0].field().boundValues = []string{strconv.Itoa(step)}
+	f := wz.forms[step]
+	if cleanedData, ok := wz.data[step]; ok && !f.IsBound() {
+		data := map[string][]string{}
+		for _, fld := range f.fields {
+			if values, ok := cleanedData[normalizedNameForField(fld)]; ok {
+				data[prefixedNameForField(fld)] = protectValues(fld.field(), fld.field().localizeValues(values))
+			}
+		}
+		f.restored = true
+		f.BindData(data, f.locale.String())
+		f.IsValid()
+	}
+}
+
+func (wz *Wizard) firstIncompleteStep() int {
+	for i := range wz.forms {
+		if _, ok := wz.data[i]; !ok {
+			return i
+		}
+	}
+	return len(wz.forms)
+}
+
+// resumeStep returns the step to display when the submitted step can't be
+// processed. It's the first incomplete step or the last step if all the
+// steps are already completed.
+func (wz *Wizard) resumeStep() int {
+	if step := wz.firstIncompleteStep(); step < len(wz.forms) {
+		return step
+	}
+	return len(wz.forms) - 1
+}
+
+func parseStep(value string, count int) (int, bool) {
+	step, err := strconv.Atoi(value)
+	if err != nil || step < 0 || step >= count {
+		return 0, false
+	}
+	return step, true
+}
+
+// CurrentStep returns the index of the current step. First step is 0.
+func (wz *Wizard) CurrentStep() int {
+	return wz.current
+}
+
+// StepCount returns the number of steps.
+func (wz *Wizard) StepCount() int {
+	return len(wz.forms)
+}
+
+// IsFirstStep returns true if the current step is the first one.
+func (wz *Wizard) IsFirstStep() bool {
+	return wz.current == 0
+}
+
+// IsLastStep returns true if the current step is the last one.
+func (wz *Wizard) IsLastStep() bool {
+	return wz.current == len(wz.forms)-1
+}
+
+// IsDone returns true if all the steps have been validated by Process.
+func (wz *Wizard) IsDone() bool {
+	return wz.done
+}
+
+// CurrentForm returns the form of the current step.
+func (wz *Wizard) CurrentForm() *Form {
+	return wz.forms[wz.current]
+}
+
+// GotoStepName returns the name of the submit button to go back to a
+// previous step. The button value is the index of the step. e.g.
+//
+//	<button type="submit" name="{{ .wizard.GotoStepName }}" value="0">Back</button>
+func (wz *Wizard) GotoStepName() string {
+	return prefixedName(wz.prefix, GotoStepFieldName)
+}
+
+// AsDiv renders the hidden field keeping track of the current step followed
+// by the form of the current step.
+func (wz *Wizard) AsDiv() template.HTML {
+	var b strings.Builder
+	for _, fld := range wz.management.Fields() {
+		b.WriteString("\n")
+		b.WriteString(string(fld.Widget()))
+	}
+	b.WriteString(string(wz.CurrentForm().AsDiv()))
+	return template.HTML(b.String())
+}
+
+// StepCleanedData returns the cleaned data saved for step. If the step is
+// not completed, it returns an empty CleanedData.
+func (wz *Wizard) StepCleanedData(step int) CleanedData {
+	cleanedData, ok := wz.data[step]
+	if !ok {
+		return CleanedData{}
+	}
+	return cleanedData
+}
+
+// CleanedData returns the cleaned data of all the completed steps combined
+// together. If two steps have a field with the same name, the last step
+// wins.
+func (wz *Wizard) CleanedData() CleanedData {
+	output := CleanedData{}
+	for i := range wz.forms {
+		for name, values := range wz.data[i] {
+			output[name] = values
+		}
+	}
+	return output
+}
@@ -0,0 +1,198 @@
+package aform_test
+
+import (
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testWizardRequest(values url.Values, cookies []*http.Cookie) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/apply", strings.NewReader(values.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	_ = req.ParseForm()
+	return req
+}
+
+func TestNewWizard_invalid(t *testing.T) {
+	a := assert.New(t)
+	_, err := aform.NewWizard(nil, aform.NewMemoryWizardStore("wizard", 0))
+	a.EqualError(err, "a wizard must have at least one step")
+	_, err = aform.NewWizard([]*aform.Form{aform.Must(aform.New())}, nil)
+	a.EqualError(err, "a wizard must have a store")
+}
+
+func TestWizard_AsDiv_unbound(t *testing.T) {
+	a := assert.New(t)
+	wz := aform.Must(aform.NewWizard([]*aform.Form{
+		aform.Must(aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))))),
+		aform.Must(aform.New(aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))))),
+	}, aform.NewMemoryWizardStore("wizard", 0)))
+	a.True(wz.IsFirstStep())
+	a.Equal(2, wz.StepCount())
+	expected := `
+<input type="hidden" name="wizard-current_step" value="0" id="id_wizard-current_step" required>
+<div><label for="id_name">Name</label><input type="text" name="name" id="id_name" maxlength="256" required></div>`
+	a.Equal(expected, string(wz.AsDiv()))
+}
+
+func TestWizard_Process(t *testing.T) {
+	stores := map[string]aform.WizardStore{
+		"memory": aform.NewMemoryWizardStore("wizard", 0),
+		"cookie": aform.NewCookieWizardStore("wizard", []byte("secret"), 0),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			a := assert.New(t)
+			newWizard := func() *aform.Wizard {
+				return aform.Must(aform.NewWizard([]*aform.Form{
+					aform.Must(aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))))),
+					aform.Must(aform.New(aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))))),
+				}, store))
+			}
+			// step 0 invalid
+			wz := newWizard()
+			rec := httptest.NewRecorder()
+			done, err := wz.Process(rec, testWizardRequest(url.Values{"wizard-current_step": {"0"}}, nil))
+			a.NoError(err)
+			a.False(done)
+			a.Equal(0, wz.CurrentStep())
+			a.True(wz.CurrentForm().Errors().Has("name"))
+			// step 0 valid
+			wz = newWizard()
+			rec = httptest.NewRecorder()
+			done, err = wz.Process(rec, testWizardRequest(url.Values{"wizard-current_step": {"0"}, "name": {"Jane"}}, nil))
+			a.NoError(err)
+			a.False(done)
+			a.Equal(1, wz.CurrentStep())
+			a.True(wz.IsLastStep())
+			cookies := rec.Result().Cookies()
+			a.Len(cookies, 1)
+			// back to step 0
+			wz = newWizard()
+			rec = httptest.NewRecorder()
+			done, err = wz.Process(rec, testWizardRequest(url.Values{"wizard-current_step": {"1"}, "wizard-goto_step": {"0"}}, cookies))
+			a.NoError(err)
+			a.False(done)
+			a.Equal(0, wz.CurrentStep())
+			a.Equal(`
+<input type="hidden" name="wizard-current_step" value="0" id="id_wizard-current_step" required>
+<div><label for="id_name">Name</label><input type="text" name="name" value="Jane" id="id_name" maxlength="256" required></div>`, string(wz.AsDiv()))
+			// step 1 valid
+			wz = newWizard()
+			rec = httptest.NewRecorder()
+			done, err = wz.Process(rec, testWizardRequest(url.Values{"wizard-current_step": {"1"}, "email": {"jane@example.com"}}, cookies))
+			a.NoError(err)
+			a.True(done)
+			a.True(wz.IsDone())
+			a.Equal(aform.CleanedData{"name": {"Jane"}, "email": {"jane@example.com"}}, wz.CleanedData())
+			a.Equal(-1, rec.Result().Cookies()[0].MaxAge)
+		})
+	}
+}
+
+func TestWizard_Process_cannotSkipStep(t *testing.T) {
+	a := assert.New(t)
+	wz := aform.Must(aform.NewWizard([]*aform.Form{
+		aform.Must(aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))))),
+		aform.Must(aform.New(aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))))),
+	}, aform.NewMemoryWizardStore("wizard", 0)))
+	done, err := wz.Process(httptest.NewRecorder(), testWizardRequest(url.Values{"wizard-current_step": {"1"}, "email": {"jane@example.com"}}, nil))
+	a.NoError(err)
+	a.False(done)
+	a.Equal(0, wz.CurrentStep())
+	a.False(wz.CurrentForm().IsBound())
+}
+
+func TestWizard_Process_loadedDataCoversAllSteps(t *testing.T) {
+	a := assert.New(t)
+	store := aform.NewCookieWizardStore("wizard", []byte("secret"), 0)
+	rec := httptest.NewRecorder()
+	data := aform.WizardData{0: {"name": {"Jane"}}, 1: {"email": {"jane@example.com"}}, 2: {"phone": {"555"}}}
+	a.NoError(store.Save(rec, testWizardRequest(url.Values{}, nil), data))
+	cookies := rec.Result().Cookies()
+	wz := aform.Must(aform.NewWizard([]*aform.Form{
+		aform.Must(aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))))),
+		aform.Must(aform.New(aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))))),
+	}, store))
+	a.NotPanics(func() {
+		done, err := wz.Process(httptest.NewRecorder(), testWizardRequest(url.Values{"wizard-current_step": {"7"}}, cookies))
+		a.NoError(err)
+		a.False(done)
+	})
+	a.Equal(1, wz.CurrentStep())
+	a.Equal(aform.CleanedData{"name": {"Jane"}, "email": {"jane@example.com"}}, wz.CleanedData())
+	a.NotPanics(func() { wz.AsDiv() })
+}
+
+func TestWizard_Process_bodyTooLarge(t *testing.T) {
+	a := assert.New(t)
+	wz := aform.Must(aform.NewWizard([]*aform.Form{
+		aform.Must(aform.New(aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))))),
+	}, aform.NewMemoryWizardStore("wizard", 0), aform.WithWizardMaxBodyBytes(8)))
+	req := httptest.NewRequest(http.MethodPost, "/apply", strings.NewReader("wizard-current_step=0&name=Jane"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	done, err := wz.Process(httptest.NewRecorder(), req)
+	a.Error(err)
+	a.False(done)
+}
+
+func TestWithWizardMaxMemory_invalid(t *testing.T) {
+	a := assert.New(t)
+	_, err := aform.NewWizard([]*aform.Form{aform.Must(aform.New())}, aform.NewMemoryWizardStore("wizard", 0), aform.WithWizardMaxMemory(0))
+	a.EqualError(err, "max memory must be greater than 0. Given: 0")
+}
+
+func TestWithWizardMaxBodyBytes_invalid(t *testing.T) {
+	a := assert.New(t)
+	_, err := aform.NewWizard([]*aform.Form{aform.Must(aform.New())}, aform.NewMemoryWizardStore("wizard", 0), aform.WithWizardMaxBodyBytes(-1))
+	a.EqualError(err, "max body bytes must be greater than 0. Given: -1")
+}
+
+type countingTokenStore struct {
+	*aform.MemoryTokenStore
+	consumed int
+}
+
+func (s *countingTokenStore) Consume(token string) (bool, error) {
+	s.consumed++
+	return s.MemoryTokenStore.Consume(token)
+}
+
+func TestWizard_Process_backToProtectedStep(t *testing.T) {
+	a := assert.New(t)
+	csrfCookie, _ := issueCSRFToken(t)
+	tokens := &countingTokenStore{MemoryTokenStore: aform.NewMemoryTokenStore(time.Hour)}
+	store := aform.NewCookieWizardStore("wizard", []byte("secret"), 0)
+	rec := httptest.NewRecorder()
+	a.NoError(store.Save(rec, testWizardRequest(url.Values{}, nil), aform.WizardData{0: {"name": {"Jane"}}}))
+	wz := aform.Must(aform.NewWizard([]*aform.Form{
+		aform.Must(aform.New(
+			aform.WithCSRF(csrfSecret),
+			aform.WithTimestamp([]byte("timestamp secret"), 0, time.Hour),
+			aform.WithOneTimeToken(tokens),
+			aform.WithCharField(aform.Must(aform.DefaultCharField("Name"))),
+		)),
+		aform.Must(aform.New(aform.WithEmailField(aform.Must(aform.DefaultEmailField("Email"))))),
+	}, store))
+	cookies := append(rec.Result().Cookies(), csrfCookie)
+	done, err := wz.Process(httptest.NewRecorder(), testWizardRequest(url.Values{"wizard-current_step": {"1"}, "wizard-goto_step": {"0"}}, cookies))
+	a.NoError(err)
+	a.False(done)
+	a.Equal(0, wz.CurrentStep())
+	a.True(wz.CurrentForm().IsValid())
+	a.Empty(wz.CurrentForm().NonFieldErrors())
+	a.Equal(0, tokens.consumed)
+	html := string(wz.AsDiv())
+	a.Contains(html, `name="name" value="Jane"`)
+	a.Contains(html, `name="csrf_token" value="`+aform.ExportSignature(csrfSecret, aform.ExportCSRFSigningPurpose, csrfCookie.Value)+`"`)
+	a.NotContains(html, `name="form_token" value=""`)
+	a.Contains(html, `name="form_token"`)
+}
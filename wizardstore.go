@@ -0,0 +1,230 @@
+package aform
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WizardData maps a step index to the cleaned data of the completed step.
+type WizardData map[int]CleanedData
+
+// WizardStore defines the interface used by a Wizard to persist the cleaned
+// data of the completed steps between requests.
+type WizardStore interface {
+	// Load returns the data saved for the user of req. If nothing has been
+	// saved yet, it returns an empty WizardData and no error.
+	Load(req *http.Request) (WizardData, error)
+	// Save saves data for the user of req.
+	Save(w http.ResponseWriter, req *http.Request, data WizardData) error
+	// Clear removes data saved for the user of req.
+	Clear(w http.ResponseWriter, req *http.Request) error
+}
+
+// verify interface compliance
+var _ WizardStore = (*MemoryWizardStore)(nil)
+var _ WizardStore = (*CookieWizardStore)(nil)
+
+const defaultWizardTTL = 24 * time.Hour
+
+// MemoryWizardStore is a WizardStore keeping data in memory. Users are
+// identified by a random ID generated by the store and saved in a cookie.
+// Data not saved again within the ttl expires. Data is lost when the
+// application restarts, and it is not shared between several instances of
+// the application. It is safe for concurrent use.
+type MemoryWizardStore struct {
+	cookieName string
+	ttl        time.Duration
+	mu         sync.Mutex
+	data       map[string]memoryWizardEntry
+	nextPrune  time.Time
+}
+
+type memoryWizardEntry struct {
+	data   WizardData
+	expiry time.Time
+}
+
+// NewMemoryWizardStore returns a MemoryWizardStore saving the user ID in the
+// cookie named cookieName. Data expires after ttl. If ttl is 0, it expires
+// after 24 hours.
+func NewMemoryWizardStore(cookieName string, ttl time.Duration) *MemoryWizardStore {
+	if ttl <= 0 {
+		ttl = defaultWizardTTL
+	}
+	return &MemoryWizardStore{
+		cookieName: cookieName,
+		ttl:        ttl,
+		data:       map[string]memoryWizardEntry{},
+	}
+}
+
+// Load returns the data saved for the user identified by the cookie. Unknown
+// and expired IDs are ignored.
+func (s *MemoryWizardStore) Load(req *http.Request) (WizardData, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entry(req, time.Now())
+	if !ok {
+		return WizardData{}, nil
+	}
+	return copyWizardData(entry.data), nil
+}
+
+// Save saves data for the user identified by the cookie. If there is no
+// cookie yet, or if its ID is unknown or expired, a new ID is generated and
+// the cookie is set on w. Expired data is removed.
+func (s *MemoryWizardStore) Save(w http.ResponseWriter, req *http.Request, data WizardData) error {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeExpired(now)
+	id := ""
+	if _, ok := s.entry(req, now); ok {
+		cookie, _ := req.Cookie(s.cookieName)
+		id = cookie.Value
+	} else {
+		id = randomToken(16)
+		http.SetCookie(w, &http.Cookie{Name: s.cookieName, Value: id, Path: "/", HttpOnly: true, SameSite: http.SameSiteLaxMode})
+	}
+	s.data[id] = memoryWizardEntry{data: copyWizardData(data), expiry: now.Add(s.ttl)}
+	return nil
+}
+
+// Clear removes data saved for the user identified by the cookie.
+func (s *MemoryWizardStore) Clear(w http.ResponseWriter, req *http.Request) error {
+	cookie, err := req.Cookie(s.cookieName)
+	if err != nil {
+		return nil
+	}
+	s.mu.Lock()
+	delete(s.data, cookie.Value)
+	s.mu.Unlock()
+	http.SetCookie(w, &http.Cookie{Name: s.cookieName, Path: "/", MaxAge: -1})
+	return nil
+}
+
+// entry returns the entry of the user identified by the cookie if it exists
+// and is not expired at now. s.mu must be held.
+func (s *MemoryWizardStore) entry(req *http.Request, now time.Time) (memoryWizardEntry, bool) {
+	cookie, err := req.Cookie(s.cookieName)
+	if err != nil {
+		return memoryWizardEntry{}, false
+	}
+	entry, ok := s.data[cookie.Value]
+	if !ok || now.After(entry.expiry) {
+		return memoryWizardEntry{}, false
+	}
+	return entry, true
+}
+
+// removeExpired removes the expired entries. The entries are visited at most
+// once per ttl. s.mu must be held.
+func (s *MemoryWizardStore) removeExpired(now time.Time) {
+	if now.Before(s.nextPrune) {
+		return
+	}
+	for id, entry := range s.data {
+		if now.After(entry.expiry) {
+			delete(s.data, id)
+		}
+	}
+	s.nextPrune = now.Add(s.ttl)
+}
+
+// wizardSigningPurpose is the purpose of the signature of the cookie of a
+// CookieWizardStore. See sign.
+const wizardSigningPurpose = "aform.wizard:"
+
+// CookieWizardStore is a WizardStore keeping data in a cookie. The cookie
+// value is signed with HMAC-SHA256 to detect any modification. It contains
+// the time it was saved and is ignored once older than the max age. Data is
+// not encrypted, it must not contain secrets. Browsers limit cookie size to
+// around 4KB.
+type CookieWizardStore struct {
+	cookieName string
+	secret     []byte
+	maxAge     time.Duration
+}
+
+// NewCookieWizardStore returns a CookieWizardStore saving data in the cookie
+// named cookieName, signed with secret. Data older than maxAge is ignored.
+// If maxAge is 0, data expires after 24 hours.
+func NewCookieWizardStore(cookieName string, secret []byte, maxAge time.Duration) *CookieWizardStore {
+	if maxAge <= 0 {
+		maxAge = defaultWizardTTL
+	}
+	return &CookieWizardStore{
+		cookieName: cookieName,
+		secret:     secret,
+		maxAge:     maxAge,
+	}
+}
+
+// Load returns the data saved in the cookie. If the cookie signature is
+// invalid, it returns ErrInvalidSignature. If the cookie is older than the
+// max age, it returns an empty WizardData and no error.
+func (s *CookieWizardStore) Load(req *http.Request) (WizardData, error) {
+	cookie, err := req.Cookie(s.cookieName)
+	if err != nil {
+		return WizardData{}, nil
+	}
+	value, err := unsign(s.secret, wizardSigningPurpose, cookie.Value)
+	if err != nil {
+		return WizardData{}, err
+	}
+	savedAt, encoded, ok := strings.Cut(value, signatureSeparator)
+	if !ok {
+		return WizardData{}, ErrInvalidSignature
+	}
+	seconds, err := strconv.ParseInt(savedAt, 10, 64)
+	if err != nil {
+		return WizardData{}, ErrInvalidSignature
+	}
+	if time.Since(time.Unix(seconds, 0)) > s.maxAge {
+		return WizardData{}, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return WizardData{}, ErrInvalidSignature
+	}
+	data := WizardData{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return WizardData{}, err
+	}
+	return data, nil
+}
+
+// Save saves data in the cookie with the current time.
+func (s *CookieWizardStore) Save(w http.ResponseWriter, _ *http.Request, data WizardData) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	payload := strconv.FormatInt(time.Now().Unix(), 10) + signatureSeparator + base64.RawURLEncoding.EncodeToString(b)
+	value := sign(s.secret, wizardSigningPurpose, payload)
+	http.SetCookie(w, &http.Cookie{Name: s.cookieName, Value: value, Path: "/", MaxAge: int(s.maxAge.Seconds()), HttpOnly: true, SameSite: http.SameSiteLaxMode})
+	return nil
+}
+
+// Clear removes the cookie.
+func (s *CookieWizardStore) Clear(w http.ResponseWriter, _ *http.Request) error {
+	http.SetCookie(w, &http.Cookie{Name: s.cookieName, Path: "/", MaxAge: -1})
+	return nil
+}
+
+func copyWizardData(data WizardData) WizardData {
+	output := WizardData{}
+	for step, cleanedData := range data {
+		copied := CleanedData{}
+		for name, values := range cleanedData {
+			copied[name] = append([]string{}, values...)
+		}
+		output[step] = copied
+	}
+	return output
+}
@@ -0,0 +1,98 @@
+package aform_test
+
+import (
+	"github.com/roleupjobboard/aform"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCookieWizardStore_SaveAndLoad(t *testing.T) {
+	a := assert.New(t)
+	store := aform.NewCookieWizardStore("wizard", []byte("secret"), 0)
+	rec := httptest.NewRecorder()
+	data := aform.WizardData{0: {"name": {"Jane"}}, 1: {"skills": {"go", "sql"}}}
+	a.NoError(store.Save(rec, httptest.NewRequest(http.MethodPost, "/", nil), data))
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.AddCookie(rec.Result().Cookies()[0])
+	loaded, err := store.Load(req)
+	a.NoError(err)
+	a.Equal(data, loaded)
+}
+
+func TestCookieWizardStore_Load_tampered(t *testing.T) {
+	a := assert.New(t)
+	rec := httptest.NewRecorder()
+	a.NoError(aform.NewCookieWizardStore("wizard", []byte("secret"), 0).Save(rec, httptest.NewRequest(http.MethodPost, "/", nil), aform.WizardData{0: {"name": {"Jane"}}}))
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.AddCookie(rec.Result().Cookies()[0])
+	_, err := aform.NewCookieWizardStore("wizard", []byte("other secret"), 0).Load(req)
+	a.ErrorIs(err, aform.ErrInvalidSignature)
+}
+
+func TestCookieWizardStore_Load_noCookie(t *testing.T) {
+	a := assert.New(t)
+	data, err := aform.NewCookieWizardStore("wizard", []byte("secret"), 0).Load(httptest.NewRequest(http.MethodPost, "/", nil))
+	a.NoError(err)
+	a.Empty(data)
+}
+
+func TestMemoryWizardStore_SaveLoadAndClear(t *testing.T) {
+	a := assert.New(t)
+	store := aform.NewMemoryWizardStore("wizard", 0)
+	rec := httptest.NewRecorder()
+	data := aform.WizardData{0: {"name": {"Jane"}}}
+	a.NoError(store.Save(rec, httptest.NewRequest(http.MethodPost, "/", nil), data))
+	cookie := rec.Result().Cookies()[0]
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.AddCookie(cookie)
+	loaded, err := store.Load(req)
+	a.NoError(err)
+	a.Equal(data, loaded)
+	a.NoError(store.Clear(httptest.NewRecorder(), req))
+	loaded, err = store.Load(req)
+	a.NoError(err)
+	a.Empty(loaded)
+}
+
+func TestCookieWizardStore_Load_expired(t *testing.T) {
+	a := assert.New(t)
+	store := aform.NewCookieWizardStore("wizard", []byte("secret"), time.Hour)
+	payload := strconv.FormatInt(time.Now().Add(-2*time.Hour).Unix(), 10) + ".eyIwIjp7Im5hbWUiOlsiSmFuZSJdfX0"
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "wizard", Value: aform.ExportSign([]byte("secret"), aform.ExportWizardSigningPurpose, payload)})
+	data, err := store.Load(req)
+	a.NoError(err)
+	a.Empty(data)
+}
+
+func TestMemoryWizardStore_Save_ignoresUnknownID(t *testing.T) {
+	a := assert.New(t)
+	store := aform.NewMemoryWizardStore("wizard", 0)
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "wizard", Value: "chosen-by-client"})
+	rec := httptest.NewRecorder()
+	a.NoError(store.Save(rec, req, aform.WizardData{0: {"name": {"Jane"}}}))
+	cookies := rec.Result().Cookies()
+	a.Len(cookies, 1)
+	a.NotEqual("chosen-by-client", cookies[0].Value)
+	loaded, err := store.Load(req)
+	a.NoError(err)
+	a.Empty(loaded)
+}
+
+func TestMemoryWizardStore_Load_expired(t *testing.T) {
+	a := assert.New(t)
+	store := aform.NewMemoryWizardStore("wizard", 50*time.Millisecond)
+	rec := httptest.NewRecorder()
+	a.NoError(store.Save(rec, httptest.NewRequest(http.MethodPost, "/", nil), aform.WizardData{0: {"name": {"Jane"}}}))
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.AddCookie(rec.Result().Cookies()[0])
+	time.Sleep(60 * time.Millisecond)
+	loaded, err := store.Load(req)
+	a.NoError(err)
+	a.Empty(loaded)
+}